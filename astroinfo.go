@@ -5,7 +5,7 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -66,31 +66,56 @@ type APIAstronomicalDailyData struct {
 }
 
 // AstronomicalInfoByCoordinates returns the AstronomicalInfo values for the given coordinates
-func (c *Client) AstronomicalInfoByCoordinates(latitude, longitude float64) (AstronomicalInfo, error) {
+func (c *Client) AstronomicalInfoByCoordinates(latitude, longitude float64, options ...CallOption,
+) (AstronomicalInfo, error) {
+	return c.AstronomicalInfoByCoordinatesContext(context.Background(), latitude, longitude, options...)
+}
+
+// AstronomicalInfoByCoordinatesContext returns the AstronomicalInfo values for the given coordinates
+// using the provided context for the API request
+func (c *Client) AstronomicalInfoByCoordinatesContext(ctx context.Context, latitude, longitude float64,
+	options ...CallOption,
+) (AstronomicalInfo, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
 	var astroInfo AstronomicalInfo
 	latitudeFormat := strconv.FormatFloat(latitude, 'f', -1, 64)
 	longitudeFormat := strconv.FormatFloat(longitude, 'f', -1, 64)
 	apiURL := fmt.Sprintf("%s/tools/astronomy/%s/%s", c.config.apiURL, latitudeFormat, longitudeFormat)
 
-	response, err := c.httpClient.Get(apiURL)
+	response, cached, err := c.getCached(ctx, call, apiURL)
 	if err != nil {
 		return astroInfo, fmt.Errorf("API request failed: %w", err)
 	}
 
-	if err = json.Unmarshal(response, &astroInfo); err != nil {
-		return astroInfo, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = c.httpClient.unmarshalResponse(ctx, apiURL, response, &astroInfo); err != nil {
+		return astroInfo, err
+	}
+	if !cached {
+		c.setCached(call, apiURL, response, cacheTTL(astroInfo.Run, DefaultCacheTTLAstronomy))
 	}
 
 	return astroInfo, nil
 }
 
 // AstronomicalInfoByLocation returns the AstronomicalInfo values for the given location
-func (c *Client) AstronomicalInfoByLocation(location string) (AstronomicalInfo, error) {
-	geoLocation, err := c.GetGeoLocationByName(location)
+func (c *Client) AstronomicalInfoByLocation(location string, options ...CallOption) (AstronomicalInfo, error) {
+	return c.AstronomicalInfoByLocationContext(context.Background(), location, options...)
+}
+
+// AstronomicalInfoByLocationContext returns the AstronomicalInfo values for the given location
+// using the provided context for the API requests
+func (c *Client) AstronomicalInfoByLocationContext(ctx context.Context, location string, options ...CallOption,
+) (AstronomicalInfo, error) {
+	ctx, cancel, _ := c.callContext(ctx, options)
+	defer cancel()
+
+	geoLocation, err := c.GetGeoLocationByNameContext(ctx, location, options...)
 	if err != nil {
 		return AstronomicalInfo{}, fmt.Errorf("failed too look up geolocation: %w", err)
 	}
-	return c.AstronomicalInfoByCoordinates(geoLocation.Latitude, geoLocation.Longitude)
+	return c.AstronomicalInfoByCoordinatesContext(ctx, geoLocation.Latitude, geoLocation.Longitude, options...)
 }
 
 // SunsetByTime returns the date and time of the sunset on the given time as DateTime type.
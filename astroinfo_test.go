@@ -19,7 +19,7 @@ func TestClient_AstronomicalInfoByCoordinates(t *testing.T) {
 	rt := time.Date(2023, 5, 28, 15, 8, 33, 0, loc)
 	nfmt := time.Date(2023, 6, 4, 5, 43, 56, 0, loc)
 	nnmt := time.Date(2023, 6, 18, 6, 39, 10, 0, loc)
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "astroinfo"))
 	ai, err := c.AstronomicalInfoByCoordinates(la, lo)
 	if err != nil {
 		t.Errorf("failed to fetch astronomical information: %s", err)
@@ -62,7 +62,7 @@ func TestClient_AstronomicalInfoByLocation(t *testing.T) {
 	rt := time.Date(2023, 5, 28, 15, 8, 33, 0, loc)
 	nfmt := time.Date(2023, 6, 4, 5, 43, 56, 0, loc)
 	nnmt := time.Date(2023, 6, 18, 6, 39, 10, 0, loc)
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "astroinfo"))
 	ai, err := c.AstronomicalInfoByLocation("Berlin, Germany")
 	if err != nil {
 		t.Errorf("failed to fetch astronomical information: %s", err)
@@ -104,7 +104,7 @@ func TestAstronomicalInfo_SunsetByDateString(t *testing.T) {
 	}
 	ti := time.Date(2023, 5, 28, 21, 16, 37, 0, loc)
 	ddt := time.Date(2023, 5, 28, 0, 0, 0, 0, time.UTC)
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "astroinfo"))
 	ai, err := c.AstronomicalInfoByCoordinates(la, lo)
 	if err != nil {
 		t.Errorf("failed to fetch astronomical information: %s", err)
@@ -167,7 +167,7 @@ func TestAstronomicalInfo_SunriseByDateString(t *testing.T) {
 	}
 	ti := time.Date(2023, 5, 28, 4, 51, 48, 0, loc)
 	ddt := time.Date(2023, 5, 28, 0, 0, 0, 0, time.UTC)
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "astroinfo"))
 	ai, err := c.AstronomicalInfoByCoordinates(la, lo)
 	if err != nil {
 		t.Errorf("failed to fetch astronomical information: %s", err)
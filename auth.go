@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// List of environment variables that hold credentials for the API user authentication
+const (
+	// EnvAPIKey is the environment variable for the API key
+	EnvAPIKey = "METEOLOGIX_API_KEY"
+	// EnvBearerToken is the environment variable for the bearer token
+	EnvBearerToken = "METEOLOGIX_BEARER_TOKEN"
+	// EnvPassword is the environment variable for the HTTP Basic auth password
+	EnvPassword = "METEOLOGIX_PASSWORD"
+	// EnvUsername is the environment variable for the HTTP Basic auth username
+	EnvUsername = "METEOLOGIX_USERNAME"
+)
+
+// List of keys in a credentials file
+const (
+	// FileKeyAPIKey is the credentials file key for the API key
+	FileKeyAPIKey = "api_key"
+	// FileKeyBearerToken is the credentials file key for the bearer token
+	FileKeyBearerToken = "bearer_token"
+	// FileKeyPassword is the credentials file key for the HTTP Basic auth password
+	FileKeyPassword = "password"
+	// FileKeyUsername is the credentials file key for the HTTP Basic auth username
+	FileKeyUsername = "username"
+)
+
+// DefaultTokenRefreshLeeway is the time before the expiry of a bearer token, at which
+// the RefreshingBearerToken fetches a new token
+const DefaultTokenRefreshLeeway = time.Second * 30
+
+var (
+	// ErrNoCredentials is returned by an Authenticator if no credentials are available
+	ErrNoCredentials = errors.New("no credentials available for API user authentication")
+	// ErrNoTokenSource is returned by NewRefreshingBearerToken if no TokenSource is provided
+	ErrNoTokenSource = errors.New("no TokenSource provided for the RefreshingBearerToken")
+)
+
+// Authenticator is the interface for supplying user authentication credentials to API
+// requests. Authenticate is called for every request to the Meteologix API, which allows
+// implementations to rotate credentials without rebuilding the Client.
+//
+// Implementations need to be safe for concurrent use.
+type Authenticator interface {
+	// Authenticate sets the user authentication for the given http.Request
+	Authenticate(ctx context.Context, request *http.Request) error
+}
+
+// TokenSource is a function that returns a new bearer token and its expiry time. A zero
+// expiry time means that the token does not expire.
+type TokenSource func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingBearerToken is an Authenticator that authenticates requests with a bearer
+// token that is fetched from a TokenSource and refreshed before it expires.
+type RefreshingBearerToken struct {
+	// expiry is the expiry time of the current token
+	expiry time.Time
+	// leeway is the time before expiry, at which the token is refreshed
+	leeway time.Duration
+	// mutex protects the token
+	mutex sync.Mutex
+	// source is the TokenSource for new tokens
+	source TokenSource
+	// token is the current bearer token
+	token string
+}
+
+// EnvAuthenticator is an Authenticator that reads the credentials from the environment
+// variables EnvAPIKey, EnvBearerToken and EnvUsername/EnvPassword on each request.
+type EnvAuthenticator struct{}
+
+// FileAuthenticator is an Authenticator that reads the credentials from a simple
+// key=value file. The file is re-read whenever its modification time changes.
+//
+// Supported keys are FileKeyAPIKey, FileKeyBearerToken, FileKeyUsername and
+// FileKeyPassword. Empty lines and lines starting with # are ignored.
+type FileAuthenticator struct {
+	// credentials holds the credentials read from the file
+	credentials credentials
+	// modTime is the modification time of the file when it was last read
+	modTime time.Time
+	// mutex protects the credentials
+	mutex sync.Mutex
+	// path is the path to the credentials file
+	path string
+}
+
+// credentials holds the different credentials for the API user authentication
+type credentials struct {
+	apiKey      string
+	bearerToken string
+	password    string
+	username    string
+}
+
+// NewRefreshingBearerToken returns a new RefreshingBearerToken for the given TokenSource
+// with the DefaultTokenRefreshLeeway. If the TokenSource is nil, ErrNoTokenSource is
+// returned
+func NewRefreshingBearerToken(source TokenSource) (*RefreshingBearerToken, error) {
+	if source == nil {
+		return nil, ErrNoTokenSource
+	}
+	return &RefreshingBearerToken{leeway: DefaultTokenRefreshLeeway, source: source}, nil
+}
+
+// Authenticate satisfies the Authenticator interface for the RefreshingBearerToken type
+func (r *RefreshingBearerToken) Authenticate(ctx context.Context, request *http.Request) error {
+	if r == nil || r.source == nil {
+		return ErrNoCredentials
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.token == "" || (!r.expiry.IsZero() && time.Now().Add(r.leeway).After(r.expiry)) {
+		token, expiry, err := r.source(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to refresh bearer token: %w", err)
+		}
+		if token == "" {
+			return ErrNoCredentials
+		}
+		r.token = token
+		r.expiry = expiry
+	}
+	credentials{bearerToken: r.token}.apply(request)
+	return nil
+}
+
+// Invalidate discards the current token, so that a new token is fetched for the next
+// request. The HTTPClient calls Invalidate when the API rejects a request with HTTP 401.
+func (r *RefreshingBearerToken) Invalidate() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.token = ""
+}
+
+// Authenticate satisfies the Authenticator interface for the EnvAuthenticator type
+func (EnvAuthenticator) Authenticate(_ context.Context, request *http.Request) error {
+	envCredentials := credentials{
+		apiKey:      os.Getenv(EnvAPIKey),
+		bearerToken: os.Getenv(EnvBearerToken),
+		password:    os.Getenv(EnvPassword),
+		username:    os.Getenv(EnvUsername),
+	}
+	if !envCredentials.apply(request) {
+		return ErrNoCredentials
+	}
+	return nil
+}
+
+// NewFileAuthenticator returns a new FileAuthenticator for the credentials file at the
+// given path
+func NewFileAuthenticator(path string) *FileAuthenticator {
+	return &FileAuthenticator{path: path}
+}
+
+// Authenticate satisfies the Authenticator interface for the FileAuthenticator type
+func (f *FileAuthenticator) Authenticate(_ context.Context, request *http.Request) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	fileInfo, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat credentials file: %w", err)
+	}
+	if !fileInfo.ModTime().Equal(f.modTime) {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		values, err := parseKeyValues(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse credentials file: %w", err)
+		}
+		f.credentials = credentials{
+			apiKey:      values[FileKeyAPIKey],
+			bearerToken: values[FileKeyBearerToken],
+			password:    values[FileKeyPassword],
+			username:    values[FileKeyUsername],
+		}
+		f.modTime = fileInfo.ModTime()
+	}
+	if !f.credentials.apply(request) {
+		return ErrNoCredentials
+	}
+	return nil
+}
+
+// apply sets the corresponding user authentication header. If an API Key is set, this
+// will be preferred, alternatively a bearer token or a username/password combination for
+// HTTP Basic auth can be used. It returns false if no credentials were set
+func (c credentials) apply(request *http.Request) bool {
+	if c.apiKey != "" {
+		request.Header.Set("X-API-Key", c.apiKey)
+		return true
+	}
+	if c.bearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.bearerToken)
+		return true
+	}
+	if c.username != "" && c.password != "" {
+		request.SetBasicAuth(url.QueryEscape(c.username), url.QueryEscape(c.password))
+		return true
+	}
+	return false
+}
+
+// parseKeyValues parses the given data as simple key=value lines. Keys are case-insensitive
+// and returned in lower case. Empty lines and lines starting with # are ignored. Values
+// can optionally be enclosed in double quotes
+func parseKeyValues(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %d: missing '='", lineNumber)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			return nil, fmt.Errorf("invalid line %d: empty key", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
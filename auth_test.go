@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_setAuthentication(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Options
+		o []Option
+		// Expected X-API-Key header
+		ek string
+		// Expected Authorization header
+		ea string
+	}{
+		{"API key", []Option{WithAPIKey("key"), WithBearerToken("token")}, "key", ""},
+		{"Bearer token", []Option{WithBearerToken("token")}, "", "Bearer token"},
+		{
+			"Basic auth", []Option{WithUsername("user"), WithPassword("pass")}, "",
+			"Basic dXNlcjpwYXNz",
+		},
+		{"None", nil, "", ""},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			c := New(tc.o...)
+			request := httptest.NewRequest(http.MethodGet, APIBaseURL+"/current/1/1", nil)
+			if err := c.httpClient.setAuthentication(context.Background(), request); err != nil {
+				t.Errorf("setAuthentication failed: %s", err)
+				return
+			}
+			if h := request.Header.Get("X-API-Key"); h != tc.ek {
+				t.Errorf("setAuthentication failed, expected X-API-Key: %q, got: %q", tc.ek, h)
+			}
+			if h := request.Header.Get("Authorization"); h != tc.ea {
+				t.Errorf("setAuthentication failed, expected Authorization: %q, got: %q", tc.ea, h)
+			}
+		})
+	}
+}
+
+func TestRefreshingBearerToken(t *testing.T) {
+	var unauthorized bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if unauthorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":401}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"description":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	expiry := time.Now().Add(time.Hour)
+	authenticator, err := NewRefreshingBearerToken(func(context.Context) (string, time.Time, error) {
+		refreshes++
+		return "token" + strconv.Itoa(refreshes), expiry, nil
+	})
+	if err != nil {
+		t.Errorf("NewRefreshingBearerToken failed: %s", err)
+		return
+	}
+	c := New(WithAPIBaseURL(server.URL), WithAuthenticator(authenticator), WithAPIKey("ignored"))
+	for i := 0; i < 2; i++ {
+		if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/current"); err != nil {
+			t.Errorf("GetWithContext with RefreshingBearerToken failed: %s", err)
+			return
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("RefreshingBearerToken failed, expected 1 refresh, got: %d", refreshes)
+	}
+	if authenticator.token != "token1" {
+		t.Errorf("RefreshingBearerToken failed, expected token: token1, got: %s", authenticator.token)
+	}
+
+	// Tokens that are about to expire are refreshed
+	authenticator.expiry = time.Now().Add(time.Second)
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/current"); err != nil {
+		t.Errorf("GetWithContext with RefreshingBearerToken failed: %s", err)
+		return
+	}
+	if refreshes != 2 {
+		t.Errorf("RefreshingBearerToken failed, expected 2 refreshes, got: %d", refreshes)
+	}
+
+	// Rejections of non-API requests do not invalidate the token
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer foreign.Close()
+	if _, err := c.httpClient.GetWithContext(context.Background(), foreign.URL+"/current"); err == nil {
+		t.Errorf("GetWithContext was expected to fail with a non-API HTTP 401, but didn't")
+	}
+	if authenticator.token != "token2" {
+		t.Errorf("RefreshingBearerToken failed, expected token: token2, got: %s", authenticator.token)
+	}
+
+	// Rejected tokens are invalidated
+	unauthorized = true
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/current"); !errors.Is(err,
+		ErrUnauthorized) {
+		t.Errorf("GetWithContext was expected to fail with ErrUnauthorized, got: %v", err)
+	}
+	if authenticator.token != "" {
+		t.Errorf("RefreshingBearerToken failed, expected token to be invalidated")
+	}
+
+	failing, err := NewRefreshingBearerToken(func(context.Context) (string, time.Time, error) {
+		return "", time.Time{}, errors.New("token endpoint unavailable")
+	})
+	if err != nil {
+		t.Errorf("NewRefreshingBearerToken failed: %s", err)
+		return
+	}
+	c = New(WithAPIBaseURL(server.URL), WithAuthenticator(failing))
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/current"); err == nil {
+		t.Errorf("GetWithContext with failing TokenSource was supposed to fail, but didn't")
+	}
+
+	if _, err = NewRefreshingBearerToken(nil); !errors.Is(err, ErrNoTokenSource) {
+		t.Errorf("NewRefreshingBearerToken with nil TokenSource was supposed to fail with ErrNoTokenSource, "+
+			"got: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodGet, server.URL+"/current", nil)
+	if err := (&RefreshingBearerToken{}).Authenticate(context.Background(), request); !errors.Is(err,
+		ErrNoCredentials) {
+		t.Errorf("RefreshingBearerToken without TokenSource was expected to fail with ErrNoCredentials, "+
+			"got: %v", err)
+	}
+}
+
+func TestEnvAuthenticator(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, APIBaseURL+"/current/1/1", nil)
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvBearerToken, "")
+	t.Setenv(EnvUsername, "")
+	t.Setenv(EnvPassword, "")
+	if err := (EnvAuthenticator{}).Authenticate(context.Background(), request); !errors.Is(err,
+		ErrNoCredentials) {
+		t.Errorf("EnvAuthenticator was expected to fail with ErrNoCredentials, got: %v", err)
+	}
+	t.Setenv(EnvBearerToken, "env-token")
+	if err := (EnvAuthenticator{}).Authenticate(context.Background(), request); err != nil {
+		t.Errorf("EnvAuthenticator failed: %s", err)
+		return
+	}
+	if h := request.Header.Get("Authorization"); h != "Bearer env-token" {
+		t.Errorf("EnvAuthenticator failed, expected Authorization: %q, got: %q", "Bearer env-token", h)
+	}
+}
+
+func TestFileAuthenticator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte("# credentials\napi_key = \"first-key\"\n"), 0o600); err != nil {
+		t.Errorf("failed to write credentials file: %s", err)
+		return
+	}
+	authenticator := NewFileAuthenticator(path)
+	request := httptest.NewRequest(http.MethodGet, APIBaseURL+"/current/1/1", nil)
+	if err := authenticator.Authenticate(context.Background(), request); err != nil {
+		t.Errorf("FileAuthenticator failed: %s", err)
+		return
+	}
+	if h := request.Header.Get("X-API-Key"); h != "first-key" {
+		t.Errorf("FileAuthenticator failed, expected X-API-Key: %q, got: %q", "first-key", h)
+	}
+
+	// Rotated credentials are picked up
+	if err := os.WriteFile(path, []byte("API_KEY=second-key\n"), 0o600); err != nil {
+		t.Errorf("failed to write credentials file: %s", err)
+		return
+	}
+	modTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Errorf("failed to change credentials file times: %s", err)
+		return
+	}
+	if err := authenticator.Authenticate(context.Background(), request); err != nil {
+		t.Errorf("FileAuthenticator failed: %s", err)
+		return
+	}
+	if h := request.Header.Get("X-API-Key"); h != "second-key" {
+		t.Errorf("FileAuthenticator failed, expected X-API-Key: %q, got: %q", "second-key", h)
+	}
+
+	if err := os.WriteFile(path, []byte("invalid line\n"), 0o600); err != nil {
+		t.Errorf("failed to write credentials file: %s", err)
+		return
+	}
+	modTime = modTime.Add(time.Minute)
+	_ = os.Chtimes(path, modTime, modTime)
+	if err := authenticator.Authenticate(context.Background(), request); err == nil {
+		t.Errorf("FileAuthenticator with invalid file was supposed to fail, but didn't")
+	}
+	if err := NewFileAuthenticator(filepath.Join(t.TempDir(), "nonexisting")).Authenticate(
+		context.Background(), request); err == nil {
+		t.Errorf("FileAuthenticator with nonexisting file was supposed to fail, but didn't")
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default number of concurrent API requests of a batch
+// method call
+const DefaultBatchConcurrency = 4
+
+// Coordinates represents a pair of latitude and longitude coordinates
+type Coordinates struct {
+	// Latitude represents the latitude coordinate
+	Latitude float64
+	// Longitude represents the longitude coordinate
+	Longitude float64
+}
+
+// CurrentWeatherResult holds the result for a single item of a CurrentWeatherBatch call
+type CurrentWeatherResult struct {
+	// Coordinates are the coordinates that have been requested
+	Coordinates Coordinates
+	// CurrentWeather holds the CurrentWeather for the Coordinates
+	CurrentWeather CurrentWeather
+	// Err holds the error of the request (if any)
+	Err error
+}
+
+// ForecastResult holds the result for a single item of a ForecastBatch call
+type ForecastResult struct {
+	// Coordinates are the coordinates that have been requested
+	Coordinates Coordinates
+	// Forecast holds the WeatherForecast for the Coordinates
+	Forecast WeatherForecast
+	// Err holds the error of the request (if any)
+	Err error
+}
+
+// CurrentWeatherBatch returns the CurrentWeather values for all the given coordinates.
+//
+// The requests are performed concurrently by a bounded pool of workers (see
+// WithBatchConcurrency). Each request respects the configured RateLimiter and Cache.
+// The returned slice holds one CurrentWeatherResult per coordinates in the same order
+// as the input. The given CallOption values are applied to each of the requests.
+func (c *Client) CurrentWeatherBatch(coordinates []Coordinates, options ...CallOption) []CurrentWeatherResult {
+	return c.CurrentWeatherBatchContext(context.Background(), coordinates, options...)
+}
+
+// CurrentWeatherBatchContext returns the CurrentWeather values for all the given coordinates
+// using the provided context for the API requests
+func (c *Client) CurrentWeatherBatchContext(ctx context.Context, coordinates []Coordinates,
+	options ...CallOption,
+) []CurrentWeatherResult {
+	results := make([]CurrentWeatherResult, len(coordinates))
+	c.batch(ctx, len(coordinates), func(ctx context.Context, index int) {
+		results[index].Coordinates = coordinates[index]
+		if err := ctx.Err(); err != nil {
+			results[index].Err = err
+			return
+		}
+		results[index].CurrentWeather, results[index].Err = c.CurrentWeatherByCoordinatesContext(ctx,
+			coordinates[index].Latitude, coordinates[index].Longitude, options...)
+	})
+	return results
+}
+
+// ForecastBatch returns the WeatherForecast values for all the given coordinates.
+//
+// The requests are performed concurrently by a bounded pool of workers (see
+// WithBatchConcurrency). Each request respects the configured RateLimiter and Cache.
+// The returned slice holds one ForecastResult per coordinates in the same order as
+// the input. The given CallOption values are applied to each of the requests.
+func (c *Client) ForecastBatch(coordinates []Coordinates, timespan Timespan, details ForecastDetails,
+	options ...CallOption,
+) []ForecastResult {
+	return c.ForecastBatchContext(context.Background(), coordinates, timespan, details, options...)
+}
+
+// ForecastBatchContext returns the WeatherForecast values for all the given coordinates
+// using the provided context for the API requests
+func (c *Client) ForecastBatchContext(ctx context.Context, coordinates []Coordinates, timespan Timespan,
+	details ForecastDetails, options ...CallOption,
+) []ForecastResult {
+	results := make([]ForecastResult, len(coordinates))
+	c.batch(ctx, len(coordinates), func(ctx context.Context, index int) {
+		results[index].Coordinates = coordinates[index]
+		if err := ctx.Err(); err != nil {
+			results[index].Err = err
+			return
+		}
+		results[index].Forecast, results[index].Err = c.ForecastByCoordinatesContext(ctx,
+			coordinates[index].Latitude, coordinates[index].Longitude, timespan, details, options...)
+	})
+	return results
+}
+
+// batch calls the given function for each index in [0, count) using a pool of workers
+// that is bounded by the configured batch concurrency. It returns once all calls have
+// finished
+func (c *Client) batch(ctx context.Context, count int, function func(context.Context, int)) {
+	workers := c.config.batchConcurrency
+	if workers > count {
+		workers = count
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				function(ctx, index)
+			}
+		}()
+	}
+	for index := 0; index < count; index++ {
+		indices <- index
+	}
+	close(indices)
+	wg.Wait()
+}
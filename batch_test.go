@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBatchTestServer returns a test server that answers current weather and forecast requests
+// with the requested coordinates and tracks the maximum number of concurrent requests. Requests
+// for latitude 99 fail with HTTP 404
+func newBatchTestServer(t *testing.T, maxInFlight *int64) *httptest.Server {
+	t.Helper()
+	var inFlight int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			highest := atomic.LoadInt64(maxInFlight)
+			if current <= highest || atomic.CompareAndSwapInt64(maxInFlight, highest, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) < 4 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if parts[2] == "99" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"detail":"Not Found"}`))
+			return
+		}
+		data := "[]"
+		if parts[1] == "current" {
+			data = "{}"
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"lat":%s,"lon":%s,"systemOfUnits":"metric","data":%s}`,
+			parts[2], parts[3], data)))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_CurrentWeatherBatch(t *testing.T) {
+	var maxInFlight int64
+	server := newBatchTestServer(t, &maxInFlight)
+	c := New(WithAPIBaseURL(server.URL), WithBatchConcurrency(3))
+
+	coordinates := make([]Coordinates, 20)
+	for i := range coordinates {
+		coordinates[i] = Coordinates{Latitude: float64(i), Longitude: float64(i) + 0.5}
+	}
+	coordinates[7].Latitude = 99
+	results := c.CurrentWeatherBatch(coordinates)
+	if len(results) != len(coordinates) {
+		t.Errorf("CurrentWeatherBatch failed, expected %d results, got: %d", len(coordinates), len(results))
+		return
+	}
+	for i, result := range results {
+		if result.Coordinates != coordinates[i] {
+			t.Errorf("CurrentWeatherBatch failed, expected coordinates: %v, got: %v", coordinates[i],
+				result.Coordinates)
+		}
+		if i == 7 {
+			if !errors.Is(result.Err, ErrNotFound) {
+				t.Errorf("CurrentWeatherBatch was expected to fail with ErrNotFound, got: %v", result.Err)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("CurrentWeatherBatch failed: %s", result.Err)
+			continue
+		}
+		if result.CurrentWeather.Latitude != coordinates[i].Latitude {
+			t.Errorf("CurrentWeatherBatch failed, expected latitude: %f, got: %f", coordinates[i].Latitude,
+				result.CurrentWeather.Latitude)
+		}
+	}
+	if maxInFlight > 3 {
+		t.Errorf("CurrentWeatherBatch failed, expected max. %d concurrent requests, got: %d", 3, maxInFlight)
+	}
+}
+
+func TestClient_ForecastBatch(t *testing.T) {
+	var maxInFlight int64
+	server := newBatchTestServer(t, &maxInFlight)
+	c := New(WithAPIBaseURL(server.URL))
+
+	coordinates := []Coordinates{{50.9833, 6.9833}, {52.52, 13.405}}
+	results := c.ForecastBatch(coordinates, Timespan1Hour, ForecastDetailStandard)
+	for i, result := range results {
+		if result.Err != nil {
+			t.Errorf("ForecastBatch failed: %s", result.Err)
+			continue
+		}
+		if result.Forecast.Longitude != coordinates[i].Longitude {
+			t.Errorf("ForecastBatch failed, expected longitude: %f, got: %f", coordinates[i].Longitude,
+				result.Forecast.Longitude)
+		}
+	}
+	if maxInFlight > DefaultBatchConcurrency {
+		t.Errorf("ForecastBatch failed, expected max. %d concurrent requests, got: %d",
+			DefaultBatchConcurrency, maxInFlight)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = c.ForecastBatchContext(ctx, coordinates, Timespan1Hour, ForecastDetailStandard)
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("ForecastBatchContext was expected to fail with context.Canceled, got: %v", result.Err)
+		}
+	}
+	if results = c.ForecastBatch(nil, Timespan1Hour, ForecastDetailStandard); len(results) != 0 {
+		t.Errorf("ForecastBatch failed, expected no results, got: %d", len(results))
+	}
+}
@@ -0,0 +1,303 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheTTLAstronomy is the maximum time astronomical information is cached.
+	// Astronomical values are calculated once a day.
+	DefaultCacheTTLAstronomy = time.Hour * 24
+	// DefaultCacheTTLCurrentWeather is the maximum time current weather data is cached.
+	// Current weather values are updated every 10 minutes.
+	DefaultCacheTTLCurrentWeather = time.Minute * 10
+	// DefaultCacheTTLForecast is the maximum time a weather forecast is cached. Forecast
+	// runs are generated hourly.
+	DefaultCacheTTLForecast = time.Hour
+	// DefaultCacheTTLObservation is the maximum time station observations are cached.
+	// Observations are updated every 10 minutes.
+	DefaultCacheTTLObservation = time.Minute * 10
+	// DefaultCacheTTLStationSearch is the time station search results are cached. Stations
+	// almost never change.
+	DefaultCacheTTLStationSearch = time.Hour * 24
+	// MinCacheTTL is the minimum time a response is cached, even if the data is expected
+	// to be updated already
+	MinCacheTTL = time.Minute
+)
+
+// FileCacheSweepInterval is the minimum interval in which Set removes the expired entries
+// from the directory of a FileCache
+const FileCacheSweepInterval = time.Hour
+
+// Cache is the interface for a response cache of the Client. Cached values are raw API
+// response payloads, keyed by the request URL and language.
+//
+// Implementations need to be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for the given key. If the key is not present or the
+	// value is expired, false is returned
+	Get(key string) ([]byte, bool)
+	// Set stores the value for the given key for the given time-to-live duration
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// MemoryCache is an in-memory Cache with a least-recently-used eviction policy
+type MemoryCache struct {
+	// capacity is the maximum number of entries in the cache
+	capacity int
+	// entries maps the cache keys to the elements of the LRU list
+	entries map[string]*list.Element
+	// lru holds the cacheEntry elements with the most recently used entry at the front
+	lru *list.List
+	// mutex protects the cache
+	mutex sync.Mutex
+}
+
+// FileCache is a file-backed Cache that stores each entry as a JSON file in a directory.
+// This allows the cache to persist across restarts of the application.
+//
+// Write errors are ignored and result in the entry not being cached. Expired entries are
+// removed when they are requested and by a sweep of the directory, which Set performs at
+// most once per FileCacheSweepInterval.
+type FileCache struct {
+	// directory is the directory path in which the cache files are stored
+	directory string
+	// lastSweep is the time when the expired entries were last removed
+	lastSweep time.Time
+	// mutex protects the cache files from concurrent writes
+	mutex sync.Mutex
+}
+
+// cacheEntry represents a single entry of a Cache
+type cacheEntry struct {
+	// Expires is the time when the cacheEntry expires
+	Expires time.Time `json:"expires"`
+	// Key is the cache key of the cacheEntry
+	Key string `json:"key"`
+	// Value is the cached value
+	Value []byte `json:"value"`
+}
+
+// NewMemoryCache returns a new MemoryCache that holds up to capacity entries. If the
+// capacity is smaller than 1, the cache size is unlimited
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Get satisfies the Cache interface for the MemoryCache type
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.Expires) {
+		m.lru.Remove(element)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.lru.MoveToFront(element)
+	return entry.Value, true
+}
+
+// Set satisfies the Cache interface for the MemoryCache type
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry := &cacheEntry{Expires: time.Now().Add(ttl), Key: key, Value: value}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.lru.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(entry)
+	if m.capacity > 0 && m.lru.Len() > m.capacity {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*cacheEntry).Key)
+	}
+}
+
+// Len returns the number of entries in the MemoryCache, including expired entries that
+// have not been evicted yet
+func (m *MemoryCache) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.lru.Len()
+}
+
+// NewFileCache returns a new FileCache that stores its entries in the given directory. The
+// directory is created, if it does not exist yet
+func NewFileCache(directory string) (*FileCache, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCache{directory: directory}, nil
+}
+
+// Get satisfies the Cache interface for the FileCache type
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	entry, err := readCacheEntry(f.path(key))
+	if err != nil || entry.Key != key {
+		return nil, false
+	}
+	if time.Now().After(entry.Expires) {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		// The entry might have been replaced by a concurrent Set in the meantime
+		entry, err = readCacheEntry(f.path(key))
+		if err != nil || entry.Key != key {
+			return nil, false
+		}
+		if time.Now().After(entry.Expires) {
+			_ = os.Remove(f.path(key))
+			return nil, false
+		}
+	}
+	return entry.Value, true
+}
+
+// Set satisfies the Cache interface for the FileCache type
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	data, err := json.Marshal(cacheEntry{Expires: time.Now().Add(ttl), Key: key, Value: value})
+	if err != nil {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if now := time.Now(); now.Sub(f.lastSweep) >= FileCacheSweepInterval {
+		f.sweep(now)
+	}
+	tempFile, err := os.CreateTemp(f.directory, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return
+	}
+	if err = os.Rename(tempFile.Name(), f.path(key)); err != nil {
+		_ = os.Remove(tempFile.Name())
+	}
+}
+
+// Sweep removes all expired entries from the directory of the FileCache
+func (f *FileCache) Sweep() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.sweep(time.Now())
+}
+
+// sweep removes all entries from the directory of the FileCache that are expired at the
+// given time. The caller needs to hold the mutex
+func (f *FileCache) sweep(now time.Time) {
+	f.lastSweep = now
+	paths, err := filepath.Glob(filepath.Join(f.directory, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, path := range paths {
+		entry, err := readCacheEntry(path)
+		if err == nil && now.After(entry.Expires) {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// path returns the file path for the given cache key
+func (f *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.directory, hex.EncodeToString(hash[:])+".json")
+}
+
+// readCacheEntry reads the cacheEntry from the file at the given path
+func readCacheEntry(path string) (cacheEntry, error) {
+	var entry cacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// getCached performs a HTTP GET request for the given URL. If a Cache is configured and
+// holds a response for the request, the cached response is returned instead and the
+// returned bool will be true. The Cache is not consulted if the method call requested
+// to bypass it
+func (c *Client) getCached(ctx context.Context, call *callConfig, url string) ([]byte, bool, error) {
+	if c.config.cache != nil && !call.bypassCache {
+		response, ok := c.config.cache.Get(cacheKey(call.acceptLang, url))
+		c.config.metrics.observeCache(c.httpClient.endpoint(url), ok)
+		if ok {
+			return response, true, nil
+		}
+	}
+	response, err := c.httpClient.get(ctx, url, call)
+	return response, false, err
+}
+
+// setCached stores the given API response for the given URL in the Cache (if configured)
+func (c *Client) setCached(call *callConfig, url string, response []byte, ttl time.Duration) {
+	if c.config.cache == nil {
+		return
+	}
+	c.config.cache.Set(cacheKey(call.acceptLang, url), response, ttl)
+}
+
+// cacheKey returns the Cache key for the given language and URL
+func cacheKey(language, url string) string {
+	return language + " " + url
+}
+
+// cacheTTL returns the time-to-live for a response, based on the time the data was
+// generated and the interval in which the data is updated. The returned TTL is within
+// MinCacheTTL and the given interval
+func cacheTTL(generated time.Time, interval time.Duration) time.Duration {
+	if generated.IsZero() {
+		return interval
+	}
+	ttl := time.Until(generated.Add(interval))
+	if ttl < MinCacheTTL {
+		return MinCacheTTL
+	}
+	if ttl > interval {
+		return interval
+	}
+	return ttl
+}
+
+// latestDateTime returns the most recent timestamp of the given APIFloat values
+func latestDateTime(values ...*APIFloat) time.Time {
+	var latest time.Time
+	for _, value := range values {
+		if value != nil && value.DateTime.After(latest) {
+			latest = value.DateTime
+		}
+	}
+	return latest
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("A"), time.Minute)
+	c.Set("b", []byte("B"), time.Minute)
+	if v, ok := c.Get("a"); !ok || string(v) != "A" {
+		t.Errorf("MemoryCache Get failed, expected: %s, got: %s", "A", v)
+	}
+	// "b" is now the least recently used entry and will be evicted
+	c.Set("c", []byte("C"), time.Minute)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("MemoryCache Get failed, expected entry to be evicted")
+	}
+	if v, ok := c.Get("c"); !ok || string(v) != "C" {
+		t.Errorf("MemoryCache Get failed, expected: %s, got: %s", "C", v)
+	}
+	if c.Len() != 2 {
+		t.Errorf("MemoryCache Len failed, expected: %d, got: %d", 2, c.Len())
+	}
+	c.Set("a", []byte("AA"), time.Minute)
+	if v, ok := c.Get("a"); !ok || string(v) != "AA" {
+		t.Errorf("MemoryCache Get failed, expected: %s, got: %s", "AA", v)
+	}
+	c.Set("expired", []byte("E"), -time.Second)
+	if _, ok := c.Get("expired"); ok {
+		t.Errorf("MemoryCache Get failed, expected expired entry to be not returned")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewFileCache(dir)
+	if err != nil {
+		t.Errorf("NewFileCache failed: %s", err)
+		return
+	}
+	c.Set("a", []byte("A"), time.Minute)
+	c.Set("expired", []byte("E"), -time.Second)
+
+	// Reopen the cache to make sure values are persisted
+	c, err = NewFileCache(dir)
+	if err != nil {
+		t.Errorf("NewFileCache failed: %s", err)
+		return
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "A" {
+		t.Errorf("FileCache Get failed, expected: %s, got: %s", "A", v)
+	}
+	if _, ok := c.Get("expired"); ok {
+		t.Errorf("FileCache Get failed, expected expired entry to be not returned")
+	}
+	if _, ok := c.Get("nonexisting"); ok {
+		t.Errorf("FileCache Get failed, expected nonexisting entry to be not returned")
+	}
+}
+
+func TestFileCache_Sweep(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewFileCache(dir)
+	if err != nil {
+		t.Errorf("NewFileCache failed: %s", err)
+		return
+	}
+	c.Set("a", []byte("A"), time.Minute)
+	c.Set("expired1", []byte("E"), -time.Second)
+	c.Set("expired2", []byte("E"), -time.Second)
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 3 {
+		t.Errorf("FileCache Set failed, expected %d files, got: %d", 3, len(files))
+	}
+	c.Sweep()
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 1 {
+		t.Errorf("FileCache Sweep failed, expected %d files, got: %d", 1, len(files))
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "A" {
+		t.Errorf("FileCache Get failed, expected: %s, got: %s", "A", v)
+	}
+
+	// Set sweeps the expired entries of a reopened cache
+	c.Set("expired1", []byte("E"), -time.Second)
+	c, err = NewFileCache(dir)
+	if err != nil {
+		t.Errorf("NewFileCache failed: %s", err)
+		return
+	}
+	c.Set("b", []byte("B"), time.Minute)
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 2 {
+		t.Errorf("FileCache Set failed, expected %d files after sweep, got: %d", 2, len(files))
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Generated time
+		g time.Time
+		// Interval
+		i time.Duration
+		// Expected minimum TTL
+		emin time.Duration
+		// Expected maximum TTL
+		emax time.Duration
+	}{
+		{"zero time", time.Time{}, time.Hour, time.Hour, time.Hour},
+		{"just generated", time.Now(), time.Hour, time.Minute * 59, time.Hour},
+		{"half interval", time.Now().Add(-time.Minute * 30), time.Hour, time.Minute * 29, time.Minute * 30},
+		{"outdated", time.Now().Add(-time.Hour * 2), time.Hour, MinCacheTTL, MinCacheTTL},
+		{"future", time.Now().Add(time.Hour * 2), time.Hour, time.Hour, time.Hour},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			ttl := cacheTTL(tc.g, tc.i)
+			if ttl < tc.emin || ttl > tc.emax {
+				t.Errorf("cacheTTL failed, expected TTL between %s and %s, got: %s", tc.emin, tc.emax, ttl)
+			}
+		})
+	}
+}
+
+func TestClient_ForecastByCoordinates_WithCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"lat":50.9833,"lon":6.9833,"run":%q,"data":[]}`,
+			time.Now().Format(time.RFC3339))))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	c := New(WithAPIBaseURL(server.URL), WithCache(cache))
+	for i := 0; i < 3; i++ {
+		if _, err := c.ForecastByCoordinates(50.9833, 6.9833, Timespan1Hour, ForecastDetailStandard); err != nil {
+			t.Errorf("ForecastByCoordinates failed: %s", err)
+			return
+		}
+	}
+	if requests != 1 {
+		t.Errorf("ForecastByCoordinates with cache failed, expected 1 request, got: %d", requests)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("ForecastByCoordinates with cache failed, expected 1 cache entry, got: %d", cache.Len())
+	}
+
+	// A different language results in a different cache key
+	c = New(WithAPIBaseURL(server.URL), WithCache(cache), WithAcceptLanguage("de"))
+	if _, err := c.ForecastByCoordinates(50.9833, 6.9833, Timespan1Hour, ForecastDetailStandard); err != nil {
+		t.Errorf("ForecastByCoordinates failed: %s", err)
+		return
+	}
+	if requests != 2 {
+		t.Errorf("ForecastByCoordinates with cache failed, expected 2 requests, got: %d", requests)
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption is a function that overrides the Client configuration for a single
+// method call, e.g. to use a different timeout or language for one API request
+type CallOption func(*callConfig)
+
+// callConfig holds the per-call configuration of a Client method call
+type callConfig struct {
+	acceptLang     string
+	bypassCache    bool
+	geoSearch      GeoSearchOptions
+	reverseGeocode bool
+	timeout        time.Duration
+	unitSystem     UnitSystem
+}
+
+// WithCallAcceptLanguage sets the Accept-Language for the API requests of a single
+// method call
+func WithCallAcceptLanguage(language string) CallOption {
+	if language == "" {
+		return nil
+	}
+	return func(call *callConfig) {
+		call.acceptLang = language
+	}
+}
+
+// WithCallCacheBypass bypasses the Cache for the API requests of a single method call.
+// The response will be requested from the API and the fresh response will be stored in
+// the Cache (if configured)
+func WithCallCacheBypass() CallOption {
+	return func(call *callConfig) {
+		call.bypassCache = true
+	}
+}
+
+// WithCallGeoSearch sets the GeoSearchOptions for the geolocation lookups of a single
+// method call, e.g. to restrict the results of GetGeoLocationsByName or of a *ByLocation
+// method to certain countries. The GeoSearchOptions only apply to the NominatimGeocoder
+func WithCallGeoSearch(options GeoSearchOptions) CallOption {
+	return func(call *callConfig) {
+		call.geoSearch = options
+	}
+}
+
+// WithCallReverseGeocoding annotates the results of a single CurrentWeather or Observation
+// method call with the GeoLocation of their coordinates, as returned by ReverseGeoLocation.
+// The GeoLocation is available via the Location field of the result. If the lookup fails,
+// the failure is logged and the Location field is left nil
+func WithCallReverseGeocoding() CallOption {
+	return func(call *callConfig) {
+		call.reverseGeocode = true
+	}
+}
+
+// WithCallTimeout sets a timeout for a single method call. The timeout covers all API
+// requests that are performed by the method call (e.g. the geolocation lookup of a
+// *ByLocation method) and is applied in addition to the deadline of a provided context
+func WithCallTimeout(timeout time.Duration) CallOption {
+	if timeout <= 0 {
+		return nil
+	}
+	return func(call *callConfig) {
+		call.timeout = timeout
+	}
+}
+
+// WithCallUnitSystem sets the UnitSystem that is requested from the API for a single
+// method call. This only applies to API endpoints that support different unit systems.
+// Unsupported unit systems are ignored
+func WithCallUnitSystem(unitSystem UnitSystem) CallOption {
+	if !unitSystem.supported() {
+		return nil
+	}
+	return func(call *callConfig) {
+		call.unitSystem = unitSystem
+	}
+}
+
+// newCallConfig returns the callConfig for a method call based on the given Config and
+// CallOption values
+func newCallConfig(config *Config, options []CallOption) *callConfig {
+	call := &callConfig{
+		acceptLang: config.acceptLang,
+		unitSystem: config.unitSystem,
+	}
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(call)
+	}
+	return call
+}
+
+// language returns the Accept-Language of the method call. If no callConfig is given or
+// it holds no Accept-Language, the given default language is returned
+func (call *callConfig) language(defaultLang string) string {
+	if call == nil || call.acceptLang == "" {
+		return defaultLang
+	}
+	return call.acceptLang
+}
+
+// callContext returns the callConfig for the given CallOption values and a context that
+// is derived from the given context and the timeout of the method call. The returned
+// context.CancelFunc must be called once the method call has finished
+func (c *Client) callContext(ctx context.Context, options []CallOption,
+) (context.Context, context.CancelFunc, *callConfig) {
+	call := newCallConfig(c.config, options)
+	if call.timeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, call.timeout)
+		return timeoutCtx, cancel, call
+	}
+	cancelCtx, cancel := context.WithCancel(ctx)
+	return cancelCtx, cancel, call
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewCallConfig(t *testing.T) {
+	c := New(WithAcceptLanguage("de"))
+	call := newCallConfig(c.config, nil)
+	if call.acceptLang != "de" {
+		t.Errorf("newCallConfig failed, expected lang: %s, got: %s", "de", call.acceptLang)
+	}
+	if call.unitSystem != UnitSystemMetric {
+		t.Errorf("newCallConfig failed, expected unit system: %s, got: %s", UnitSystemMetric, call.unitSystem)
+	}
+	call = newCallConfig(c.config, []CallOption{
+		WithCallAcceptLanguage("en"), WithCallCacheBypass(),
+		WithCallTimeout(time.Second), WithCallUnitSystem(UnitSystemImperial),
+		WithCallAcceptLanguage(""), WithCallTimeout(0), WithCallUnitSystem(""), WithCallUnitSystem("kelvin"), nil,
+	})
+	if call.acceptLang != "en" {
+		t.Errorf("newCallConfig failed, expected lang: %s, got: %s", "en", call.acceptLang)
+	}
+	if !call.bypassCache {
+		t.Errorf("newCallConfig failed, expected cache bypass to be set")
+	}
+	if call.timeout != time.Second {
+		t.Errorf("newCallConfig failed, expected timeout: %s, got: %s", time.Second, call.timeout)
+	}
+	if call.unitSystem != UnitSystemImperial {
+		t.Errorf("newCallConfig failed, expected unit system: %s, got: %s", UnitSystemImperial,
+			call.unitSystem)
+	}
+}
+
+func TestClient_CurrentWeatherByCoordinates_CallOptions(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r)
+		mutex.Unlock()
+		if r.Header.Get("Accept-Language") == "slow" {
+			time.Sleep(time.Millisecond * 200)
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"lat":50.9833,"lon":6.9833,"systemOfUnits":"` + r.URL.Query().Get("units") +
+			`","data":{}}`))
+	}))
+	defer server.Close()
+
+	c := New(WithAPIBaseURL(server.URL), WithAcceptLanguage("de"), WithCache(NewMemoryCache(10)))
+	if _, err := c.CurrentWeatherByCoordinates(50.9833, 6.9833); err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	cw, err := c.CurrentWeatherByCoordinates(50.9833, 6.9833, WithCallAcceptLanguage("en"),
+		WithCallUnitSystem(UnitSystemImperial))
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates with call options failed: %s", err)
+		return
+	}
+	if cw.Units() != UnitSystemImperial {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected unit system: %s, got: %s", UnitSystemImperial,
+			cw.Units())
+	}
+	// Served from the Cache
+	if _, err = c.CurrentWeatherByCoordinates(50.9833, 6.9833); err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	if _, err = c.CurrentWeatherByCoordinates(50.9833, 6.9833, WithCallCacheBypass()); err != nil {
+		t.Errorf("CurrentWeatherByCoordinates with cache bypass failed: %s", err)
+		return
+	}
+
+	mutex.Lock()
+	if len(requests) != 3 {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected %d requests, got: %d", 3, len(requests))
+	}
+	if len(requests) > 1 {
+		if l := requests[0].Header.Get("Accept-Language"); l != "de" {
+			t.Errorf("CurrentWeatherByCoordinates failed, expected Accept-Language: %s, got: %s", "de", l)
+		}
+		if l := requests[1].Header.Get("Accept-Language"); l != "en" {
+			t.Errorf("CurrentWeatherByCoordinates failed, expected Accept-Language: %s, got: %s", "en", l)
+		}
+	}
+	mutex.Unlock()
+
+	_, err = c.CurrentWeatherByCoordinatesContext(context.Background(), 50.9833, 6.9833,
+		WithCallAcceptLanguage("slow"), WithCallTimeout(time.Millisecond*20))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CurrentWeatherByCoordinatesContext was expected to fail with context.DeadlineExceeded, "+
+			"got: %v", err)
+	}
+}
+
+func TestClient_CurrentWeatherByCoordinates_CallUnitSystem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if r.URL.Query().Get("units") == string(UnitSystemImperial) {
+			_, _ = w.Write([]byte(`{"systemOfUnits":"imperial","data":{` +
+				`"temp":{"dateTime":"2023-05-28T12:00:00Z","value":68}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"systemOfUnits":"metric","data":{` +
+			`"temp":{"dateTime":"2023-05-28T12:00:00Z","value":20}}}`))
+	}))
+	defer server.Close()
+
+	c := New(WithAPIBaseURL(server.URL))
+	tt := []struct {
+		// Test name
+		n string
+		// Call options
+		o []CallOption
+		// Expected unit system of the response
+		us UnitSystem
+	}{
+		{"Default unit system", nil, UnitSystemMetric},
+		{
+			"Per-call imperial unit system", []CallOption{WithCallUnitSystem(UnitSystemImperial)},
+			UnitSystemImperial,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			cw, err := c.CurrentWeatherByCoordinates(50.9833, 6.9833, tc.o...)
+			if err != nil {
+				t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+				return
+			}
+			if cw.Units() != tc.us {
+				t.Errorf("CurrentWeatherByCoordinates failed, expected unit system: %s, got: %s", tc.us,
+					cw.Units())
+			}
+			if v := cw.Temperature().String(); v != "20.0°C" {
+				t.Errorf("CurrentWeatherByCoordinates failed, expected temperature: %s, got: %s", "20.0°C", v)
+			}
+		})
+	}
+}
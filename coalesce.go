@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests, so that only one upstream request
+// is performed and its result is shared with all callers
+type flightGroup struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// flight represents an in-flight request of a flightGroup
+type flight struct {
+	body []byte
+	done chan struct{}
+	err  error
+}
+
+// do calls the given function for the given key, unless a call for the same key is already
+// in flight. In that case it waits for the in-flight call and returns its result instead.
+//
+// If the shared call failed due to the cancellation of the context of the calling goroutine
+// that started it, while the context of a waiting caller is still valid, the waiting caller
+// performs the call on its own
+func (g *flightGroup) do(ctx context.Context, key string, function func() ([]byte, error)) ([]byte, error) {
+	for {
+		g.mutex.Lock()
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+		if current, ok := g.flights[key]; ok {
+			g.mutex.Unlock()
+			select {
+			case <-current.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if isContextError(current.err) && ctx.Err() == nil {
+				continue
+			}
+			return current.body, current.err
+		}
+		current := &flight{done: make(chan struct{})}
+		g.flights[key] = current
+		g.mutex.Unlock()
+
+		g.call(key, current, function)
+		return current.body, current.err
+	}
+}
+
+// call performs the given function for the given flight and releases the flight afterwards,
+// so that waiting callers are notified. If the function panics, the waiting callers receive
+// an error and the panic is propagated to the calling goroutine
+func (g *flightGroup) call(key string, current *flight, function func() ([]byte, error)) {
+	defer func() {
+		recovered := recover()
+		if recovered != nil {
+			current.body, current.err = nil, fmt.Errorf("coalesced request panicked: %v", recovered)
+		}
+		g.mutex.Lock()
+		delete(g.flights, key)
+		g.mutex.Unlock()
+		close(current.done)
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+	current.body, current.err = function()
+}
+
+// isContextError returns true if the given error is caused by a canceled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// coalesceKey returns the key under which identical requests are coalesced. It consists of the
+// Accept-Language of the request and the normalized URL, so that URLs that only differ in the
+// case of scheme and host or in the order of the query parameters are considered identical
+func coalesceKey(language, rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return language + " " + rawURL
+	}
+	parsedURL.Scheme = strings.ToLower(parsedURL.Scheme)
+	parsedURL.Host = strings.ToLower(parsedURL.Host)
+	parsedURL.RawQuery = parsedURL.Query().Encode()
+	parsedURL.Fragment = ""
+	return language + " " + parsedURL.String()
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_GetWithContext_Coalescing(t *testing.T) {
+	var requests int64
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := New()
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	errs := make([]error, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			url := server.URL + "/forecast?b=2&a=1"
+			if index%2 == 0 {
+				url = server.URL + "/forecast?a=1&b=2"
+			}
+			body, err := c.httpClient.GetWithContext(context.Background(), url)
+			bodies[index], errs[index] = string(body), err
+			// Each caller receives its own copy, which it is free to modify
+			if len(body) > 0 {
+				body[0] = '['
+			}
+		}(i)
+	}
+	time.Sleep(time.Millisecond * 100)
+	close(release)
+	wg.Wait()
+
+	if r := atomic.LoadInt64(&requests); r != 1 {
+		t.Errorf("HTTPClient GetWithContext coalescing failed, expected 1 request, got: %d", r)
+	}
+	for i := range bodies {
+		if errs[i] != nil {
+			t.Errorf("HTTPClient GetWithContext coalescing failed: %s", errs[i])
+		}
+		if bodies[i] != `{"ok":true}` {
+			t.Errorf("HTTPClient GetWithContext coalescing failed, expected body: %s, got: %s", `{"ok":true}`,
+				bodies[i])
+		}
+	}
+
+	// Requests that are not in flight at the same time are not coalesced
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/forecast?a=1&b=2"); err != nil {
+		t.Errorf("HTTPClient GetWithContext failed: %s", err)
+	}
+	if r := atomic.LoadInt64(&requests); r != 2 {
+		t.Errorf("HTTPClient GetWithContext failed, expected 2 requests, got: %d", r)
+	}
+}
+
+func TestFlightGroup_do(t *testing.T) {
+	group := &flightGroup{}
+	started := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	var calls int64
+	function := func() ([]byte, error) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			close(started)
+			<-leaderCtx.Done()
+			return nil, leaderCtx.Err()
+		}
+		return []byte("follower"), nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := group.do(leaderCtx, "key", function); !errors.Is(err, context.Canceled) {
+			t.Errorf("flightGroup do was expected to fail with context.Canceled, got: %v", err)
+		}
+	}()
+	<-started
+
+	var body []byte
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		body, err = group.do(context.Background(), "key", function)
+	}()
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	wg.Wait()
+
+	// The waiting caller is not affected by the canceled context of the first caller
+	if err != nil {
+		t.Errorf("flightGroup do failed: %s", err)
+	}
+	if string(body) != "follower" {
+		t.Errorf("flightGroup do failed, expected body: %s, got: %s", "follower", body)
+	}
+
+	ctx, cancelWaiter := context.WithCancel(context.Background())
+	cancelWaiter()
+	block := make(chan struct{})
+	go func() {
+		_, _ = group.do(context.Background(), "blocked", func() ([]byte, error) {
+			<-block
+			return nil, nil
+		})
+	}()
+	time.Sleep(time.Millisecond * 20)
+	if _, err = group.do(ctx, "blocked", function); !errors.Is(err, context.Canceled) {
+		t.Errorf("flightGroup do was expected to fail with context.Canceled, got: %v", err)
+	}
+	close(block)
+}
+
+func TestFlightGroup_do_Panic(t *testing.T) {
+	group := &flightGroup{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("flightGroup do was expected to propagate the panic to the leader")
+			}
+		}()
+		_, _ = group.do(context.Background(), "key", func() ([]byte, error) {
+			close(started)
+			<-release
+			panic("hook failed")
+		})
+	}()
+	<-started
+
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err = group.do(context.Background(), "key", func() ([]byte, error) { return nil, nil })
+	}()
+	time.Sleep(time.Millisecond * 20)
+	close(release)
+	wg.Wait()
+	if err == nil {
+		t.Errorf("flightGroup do was expected to fail for the waiting caller after a panic")
+	}
+
+	// The flight is released, so later calls are performed
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	body, err := group.do(ctx, "key", func() ([]byte, error) { return []byte("ok"), nil })
+	if err != nil || string(body) != "ok" {
+		t.Errorf("flightGroup do failed after a panic, expected body: ok, got: %s, %v", body, err)
+	}
+}
+
+func TestCoalesceKey(t *testing.T) {
+	tt := []struct {
+		// First URL
+		a string
+		// Second URL
+		b string
+		// Expected to be identical
+		e bool
+	}{
+		{
+			"https://example.com/v02/current?units=metric&a=1",
+			"https://EXAMPLE.com/v02/current?a=1&units=metric", true,
+		},
+		{"https://example.com/v02/current/1/2", "https://example.com/v02/current/1/2#fragment", true},
+		{"https://example.com/v02/current/1/2", "https://example.com/v02/current/2/1", false},
+		{"https://example.com/v02/Current", "https://example.com/v02/current", false},
+	}
+	for _, tc := range tt {
+		if (coalesceKey("de", tc.a) == coalesceKey("de", tc.b)) != tc.e {
+			t.Errorf("coalesceKey failed for %q and %q, expected identical keys: %t", tc.a, tc.b, tc.e)
+		}
+	}
+	if coalesceKey("de", "https://example.com") == coalesceKey("en", "https://example.com") {
+		t.Errorf("coalesceKey failed, expected different keys for different languages")
+	}
+}
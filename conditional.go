@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// DefaultConditionalCapacity is the default number of responses for which the validators
+// (ETag, Last-Modified) and payloads are kept for conditional requests
+const DefaultConditionalCapacity = 128
+
+// validatorStore keeps the validators and payloads of API responses, so that subsequent
+// requests for the same resource can be sent as conditional requests. It uses a
+// least-recently-used eviction policy
+type validatorStore struct {
+	// capacity is the maximum number of entries in the store
+	capacity int
+	// entries maps the request keys to the elements of the LRU list
+	entries map[string]*list.Element
+	// lru holds the validatedResponse elements with the most recently used entry at the front
+	lru *list.List
+	// mutex protects the store
+	mutex sync.Mutex
+}
+
+// validatedResponse holds the validators and the payload of an API response
+type validatedResponse struct {
+	// body is the response payload
+	body []byte
+	// etag is the value of the ETag header of the response
+	etag string
+	// key is the request key of the validatedResponse
+	key string
+	// lastModified is the value of the Last-Modified header of the response
+	lastModified string
+}
+
+// newValidatorStore returns a new validatorStore that holds up to capacity entries. If the
+// capacity is smaller than 1, nil is returned and conditional requests are disabled
+func newValidatorStore(capacity int) *validatorStore {
+	if capacity < 1 {
+		return nil
+	}
+	return &validatorStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// apply sets the If-None-Match and If-Modified-Since headers of the given request, if
+// validators are stored for the given key. The validatedResponse is returned, so that its
+// payload can be used if the API responds with HTTP 304
+func (v *validatorStore) apply(key string, request *http.Request) *validatedResponse {
+	if v == nil {
+		return nil
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	element, ok := v.entries[key]
+	if !ok {
+		return nil
+	}
+	v.lru.MoveToFront(element)
+	validated := element.Value.(*validatedResponse)
+	if validated.etag != "" {
+		request.Header.Set("If-None-Match", validated.etag)
+	}
+	if validated.lastModified != "" {
+		request.Header.Set("If-Modified-Since", validated.lastModified)
+	}
+	return validated
+}
+
+// store stores the validators and a copy of the payload of the given response for the given
+// key. If the response holds no validators, a previously stored entry for the key is removed
+func (v *validatorStore) store(key string, response *http.Response, body []byte) {
+	if v == nil {
+		return
+	}
+	validated := &validatedResponse{
+		body:         bytes.Clone(body),
+		etag:         response.Header.Get("ETag"),
+		key:          key,
+		lastModified: response.Header.Get("Last-Modified"),
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	element, ok := v.entries[key]
+	if validated.etag == "" && validated.lastModified == "" {
+		if ok {
+			v.lru.Remove(element)
+			delete(v.entries, key)
+		}
+		return
+	}
+	if ok {
+		element.Value = validated
+		v.lru.MoveToFront(element)
+		return
+	}
+	v.entries[key] = v.lru.PushFront(validated)
+	if v.lru.Len() > v.capacity {
+		oldest := v.lru.Back()
+		v.lru.Remove(oldest)
+		delete(v.entries, oldest.Value.(*validatedResponse).key)
+	}
+}
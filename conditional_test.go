@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_GetWithContext_Conditional(t *testing.T) {
+	lastModified := "Sun, 28 May 2023 12:00:00 GMT"
+	tt := []struct {
+		// Test name
+		n string
+		// Client options
+		o []Option
+		// Response header name
+		h string
+		// Response header value
+		v string
+		// Conditional request header name
+		ch string
+		// Expected number of HTTP 304 responses
+		e int
+	}{
+		{"ETag", nil, "ETag", `"v1"`, "If-None-Match", 2},
+		{"Last-Modified", nil, "Last-Modified", lastModified, "If-Modified-Since", 2},
+		{"No validators", nil, "X-Validator", "none", "If-None-Match", 0},
+		{"Disabled", []Option{WithConditionalRequests(0)}, "ETag", `"v1"`, "If-None-Match", 0},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			notModified := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(tc.ch) == tc.v {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Content-Type", MIMETypeJSON)
+				w.Header().Set(tc.h, tc.v)
+				_, _ = w.Write([]byte(`{"run":1}`))
+			}))
+			defer server.Close()
+
+			c := New(tc.o...)
+			for i := 0; i < 3; i++ {
+				body, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/forecast")
+				if err != nil {
+					t.Errorf("HTTPClient GetWithContext failed: %s", err)
+					return
+				}
+				if string(body) != `{"run":1}` {
+					t.Errorf("HTTPClient GetWithContext failed, expected body: %s, got: %s", `{"run":1}`, body)
+				}
+				// Modifying the returned body must not affect the stored payload
+				body[0] = '['
+			}
+			if notModified != tc.e {
+				t.Errorf("HTTPClient GetWithContext failed, expected %d HTTP 304 responses, got: %d", tc.e,
+					notModified)
+			}
+		})
+	}
+}
+
+func TestValidatorStore(t *testing.T) {
+	store := newValidatorStore(2)
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("ETag", `"a"`)
+	store.store("a", response, []byte("A"))
+	response.Header.Set("ETag", `"b"`)
+	store.store("b", response, []byte("B"))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if validated := store.apply("a", request); validated == nil || string(validated.body) != "A" {
+		t.Errorf("validatorStore apply failed, expected stored response for key: %s", "a")
+	}
+	if h := request.Header.Get("If-None-Match"); h != `"a"` {
+		t.Errorf("validatorStore apply failed, expected If-None-Match: %s, got: %s", `"a"`, h)
+	}
+	// "b" is now the least recently used entry and will be evicted
+	response.Header.Set("ETag", `"c"`)
+	store.store("c", response, []byte("C"))
+	if validated := store.apply("b", httptest.NewRequest(http.MethodGet, "/", nil)); validated != nil {
+		t.Errorf("validatorStore apply failed, expected entry to be evicted")
+	}
+	// Responses without validators remove the stored entry
+	store.store("a", &http.Response{Header: http.Header{}}, []byte("A"))
+	if validated := store.apply("a", httptest.NewRequest(http.MethodGet, "/", nil)); validated != nil {
+		t.Errorf("validatorStore apply failed, expected entry to be removed")
+	}
+
+	store = newValidatorStore(0)
+	if store != nil {
+		t.Errorf("newValidatorStore failed, expected nil store for capacity 0")
+	}
+	store.store("a", response, []byte("A"))
+	if validated := store.apply("a", httptest.NewRequest(http.MethodGet, "/", nil)); validated != nil {
+		t.Errorf("validatorStore apply failed, expected nil store to return no entry")
+	}
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List of environment variables that hold Client configuration settings (in addition
+// to the credential environment variables EnvAPIKey, EnvBearerToken, EnvUsername and
+// EnvPassword)
+const (
+	// EnvAPIURL is the environment variable for the base URL of the Meteologix API
+	EnvAPIURL = "METEOLOGIX_API_URL"
+	// EnvGeocoderURL is the environment variable for the base URL of the OSM Nominatim API
+	EnvGeocoderURL = "METEOLOGIX_GEOCODER_URL"
+	// EnvLanguage is the environment variable for the Accept-Language of API requests
+	EnvLanguage = "METEOLOGIX_LANGUAGE"
+	// EnvTimeout is the environment variable for the HTTP request timeout. The value is
+	// either a Go duration string (e.g. "15s") or a number of seconds
+	EnvTimeout = "METEOLOGIX_TIMEOUT"
+	// EnvUserAgent is the environment variable for the User-Agent of API requests
+	EnvUserAgent = "METEOLOGIX_USER_AGENT"
+)
+
+// List of keys in a configuration file (in addition to the credential keys FileKeyAPIKey,
+// FileKeyBearerToken, FileKeyUsername and FileKeyPassword)
+const (
+	// FileKeyAPIURL is the configuration file key for the base URL of the Meteologix API
+	FileKeyAPIURL = "api_url"
+	// FileKeyGeocoderURL is the configuration file key for the base URL of the OSM
+	// Nominatim API
+	FileKeyGeocoderURL = "geocoder_url"
+	// FileKeyLanguage is the configuration file key for the Accept-Language of API requests
+	FileKeyLanguage = "language"
+	// FileKeyTimeout is the configuration file key for the HTTP request timeout
+	FileKeyTimeout = "timeout"
+	// FileKeyUserAgent is the configuration file key for the User-Agent of API requests
+	FileKeyUserAgent = "user_agent"
+)
+
+// envFileKeys maps the configuration environment variables to the configuration file keys
+var envFileKeys = map[string]string{
+	EnvAPIKey:      FileKeyAPIKey,
+	EnvAPIURL:      FileKeyAPIURL,
+	EnvBearerToken: FileKeyBearerToken,
+	EnvGeocoderURL: FileKeyGeocoderURL,
+	EnvLanguage:    FileKeyLanguage,
+	EnvPassword:    FileKeyPassword,
+	EnvTimeout:     FileKeyTimeout,
+	EnvUserAgent:   FileKeyUserAgent,
+	EnvUsername:    FileKeyUsername,
+}
+
+// NewFromEnv returns a new Meteologix API Client that is configured from the METEOLOGIX_*
+// environment variables (see EnvAPIKey, EnvLanguage, EnvTimeout, etc.).
+//
+// The given Option values are applied after the environment configuration, so they take
+// precedence over it.
+func NewFromEnv(options ...Option) (*Client, error) {
+	values := make(map[string]string)
+	for envKey, fileKey := range envFileKeys {
+		if value := os.Getenv(envKey); value != "" {
+			values[fileKey] = value
+		}
+	}
+	return newFromValues(values, options)
+}
+
+// NewFromConfigFile returns a new Meteologix API Client that is configured from the
+// simple key=value configuration file at the given path. Empty lines and lines starting
+// with # are ignored.
+//
+// Supported keys are FileKeyAPIKey, FileKeyBearerToken, FileKeyUsername, FileKeyPassword,
+// FileKeyLanguage, FileKeyTimeout, FileKeyUserAgent, FileKeyAPIURL and FileKeyGeocoderURL.
+//
+// The given Option values are applied after the file configuration, so they take
+// precedence over it.
+func NewFromConfigFile(path string, options ...Option) (*Client, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	values, err := parseKeyValues(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	for key := range values {
+		if !isConfigFileKey(key) {
+			return nil, fmt.Errorf("failed to parse config file: unsupported key %q", key)
+		}
+	}
+	return newFromValues(values, options)
+}
+
+// newFromValues returns a new Client configured from the given configuration file key
+// values, followed by the given Option values
+func newFromValues(values map[string]string, options []Option) (*Client, error) {
+	configOptions := []Option{
+		WithAPIKey(values[FileKeyAPIKey]),
+		WithBearerToken(values[FileKeyBearerToken]),
+		WithUsername(values[FileKeyUsername]),
+		WithPassword(values[FileKeyPassword]),
+		WithAcceptLanguage(values[FileKeyLanguage]),
+		WithUserAgent(values[FileKeyUserAgent]),
+		WithAPIBaseURL(values[FileKeyAPIURL]),
+		WithGeocoderURL(values[FileKeyGeocoderURL]),
+	}
+	if value := values[FileKeyTimeout]; value != "" {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return nil, err
+		}
+		configOptions = append(configOptions, WithTimeout(timeout))
+	}
+	return New(append(configOptions, options...)...), nil
+}
+
+// parseTimeout parses a timeout value, which is either a Go duration string or a
+// number of seconds
+func parseTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0, fmt.Errorf("invalid timeout value: %s", value)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout value: %s", value)
+	}
+	return timeout, nil
+}
+
+// isConfigFileKey returns true if the given key is a supported configuration file key
+func isConfigFileKey(key string) bool {
+	for _, fileKey := range envFileKeys {
+		if strings.EqualFold(key, fileKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	for envKey := range envFileKeys {
+		t.Setenv(envKey, "")
+	}
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvLanguage, "en")
+	t.Setenv(EnvTimeout, "5")
+	t.Setenv(EnvUserAgent, "env-agent")
+	t.Setenv(EnvAPIURL, "https://api.example.com/v02/")
+	c, err := NewFromEnv(WithAcceptLanguage("fr"))
+	if err != nil {
+		t.Errorf("NewFromEnv failed: %s", err)
+		return
+	}
+	if c.config.apiKey != "env-key" {
+		t.Errorf("NewFromEnv failed, expected API key: %s, got: %s", "env-key", c.config.apiKey)
+	}
+	if c.config.acceptLang != "fr" {
+		t.Errorf("NewFromEnv failed, expected explicit option to take precedence, got lang: %s",
+			c.config.acceptLang)
+	}
+	if c.httpClient.Timeout != time.Second*5 {
+		t.Errorf("NewFromEnv failed, expected timeout: %s, got: %s", time.Second*5, c.httpClient.Timeout)
+	}
+	if c.config.userAgent != "env-agent" {
+		t.Errorf("NewFromEnv failed, expected user agent: %s, got: %s", "env-agent", c.config.userAgent)
+	}
+	if c.config.apiURL != "https://api.example.com/v02" {
+		t.Errorf("NewFromEnv failed, expected API URL: %s, got: %s", "https://api.example.com/v02",
+			c.config.apiURL)
+	}
+	if c.config.geocoderURL != OSMNominatimBaseURL {
+		t.Errorf("NewFromEnv failed, expected geocoder URL: %s, got: %s", OSMNominatimBaseURL,
+			c.config.geocoderURL)
+	}
+
+	t.Setenv(EnvTimeout, "soon")
+	if _, err = NewFromEnv(); err == nil {
+		t.Errorf("NewFromEnv with invalid timeout was supposed to fail")
+	}
+}
+
+func TestNewFromConfigFile(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Config file content
+		c string
+		// Expected API key
+		k string
+		// Expected timeout
+		to time.Duration
+		// Should fail
+		sf bool
+	}{
+		{"Full config", "# meteologix\napi_key = \"file-key\"\nlanguage = en\ntimeout = 2m\n" +
+			"user_agent = file-agent\napi_url = https://api.example.com/v02\n" +
+			"geocoder_url = https://nominatim.example.com\n", "file-key", time.Minute * 2, false},
+		{"Timeout in seconds", "bearer_token = token\ntimeout = 1.5\n", "", time.Millisecond * 1500, false},
+		{"Empty config", "", "", HTTPClientTimeout, false},
+		{"Invalid timeout", "timeout = -1\n", "", 0, true},
+		{"Unsupported key", "api_secret = secret\n", "", 0, true},
+		{"Invalid line", "api_key\n", "", 0, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "meteologix.conf")
+			if err := os.WriteFile(path, []byte(tc.c), 0o600); err != nil {
+				t.Errorf("failed to write config file: %s", err)
+				return
+			}
+			c, err := NewFromConfigFile(path)
+			if err != nil && !tc.sf {
+				t.Errorf("NewFromConfigFile failed: %s", err)
+				return
+			}
+			if tc.sf {
+				if err == nil {
+					t.Errorf("NewFromConfigFile was supposed to fail, but didn't")
+				}
+				return
+			}
+			if c.config.apiKey != tc.k {
+				t.Errorf("NewFromConfigFile failed, expected API key: %s, got: %s", tc.k, c.config.apiKey)
+			}
+			if c.httpClient.Timeout != tc.to {
+				t.Errorf("NewFromConfigFile failed, expected timeout: %s, got: %s", tc.to,
+					c.httpClient.Timeout)
+			}
+		})
+	}
+	if _, err := NewFromConfigFile(filepath.Join(t.TempDir(), "missing.conf")); err == nil {
+		t.Errorf("NewFromConfigFile with missing file was supposed to fail")
+	}
+	path := filepath.Join(t.TempDir(), "meteologix.conf")
+	if err := os.WriteFile(path, []byte("api_key = file-key\n"), 0o600); err != nil {
+		t.Errorf("failed to write config file: %s", err)
+		return
+	}
+	c, err := NewFromConfigFile(path, WithAPIKey("option-key"))
+	if err != nil {
+		t.Errorf("NewFromConfigFile failed: %s", err)
+		return
+	}
+	if c.config.apiKey != "option-key" {
+		t.Errorf("NewFromConfigFile failed, expected explicit option to take precedence, got API key: %s",
+			c.config.apiKey)
+	}
+}
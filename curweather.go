@@ -5,10 +5,11 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // CurrentWeather represents the current weather API response
@@ -17,6 +18,9 @@ type CurrentWeather struct {
 	Data APICurrentWeatherData `json:"data"`
 	// Latitude represents the GeoLocation latitude coordinates for the weather data
 	Latitude float64 `json:"lat"`
+	// Location is the reverse geocoded GeoLocation of the coordinates. It is only set if
+	// requested via WithCallReverseGeocoding and a location was found for the coordinates
+	Location *GeoLocation `json:"-"`
 	// Longitude represents the GeoLocation longitude coordinates for the weather data
 	Longitude float64 `json:"lon"`
 	// UnitSystem is the unit system that is used for the results (we default to metric)
@@ -70,7 +74,19 @@ type APICurrentWeatherData struct {
 }
 
 // CurrentWeatherByCoordinates returns the CurrentWeather values for the given coordinates
-func (c *Client) CurrentWeatherByCoordinates(latitude, longitude float64) (CurrentWeather, error) {
+func (c *Client) CurrentWeatherByCoordinates(latitude, longitude float64, options ...CallOption,
+) (CurrentWeather, error) {
+	return c.CurrentWeatherByCoordinatesContext(context.Background(), latitude, longitude, options...)
+}
+
+// CurrentWeatherByCoordinatesContext returns the CurrentWeather values for the given coordinates
+// using the provided context for the API request
+func (c *Client) CurrentWeatherByCoordinatesContext(ctx context.Context, latitude, longitude float64,
+	options ...CallOption,
+) (CurrentWeather, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
 	var currentWeather CurrentWeather
 	latitudeFormat := strconv.FormatFloat(latitude, 'f', -1, 64)
 	longitudeFormat := strconv.FormatFloat(longitude, 'f', -1, 64)
@@ -79,28 +95,54 @@ func (c *Client) CurrentWeatherByCoordinates(latitude, longitude float64) (Curre
 		return currentWeather, fmt.Errorf("failed to parse current weather URL: %w", err)
 	}
 	queryString := apiURL.Query()
-	queryString.Add("units", "metric")
+	queryString.Add("units", string(call.unitSystem))
 	apiURL.RawQuery = queryString.Encode()
 
-	response, err := c.httpClient.Get(apiURL.String())
+	response, cached, err := c.getCached(ctx, call, apiURL.String())
 	if err != nil {
 		return currentWeather, fmt.Errorf("API request failed: %w", err)
 	}
 
-	if err := json.Unmarshal(response, &currentWeather); err != nil {
-		return currentWeather, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = c.httpClient.unmarshalResponse(ctx, apiURL.String(), response, &currentWeather); err != nil {
+		return currentWeather, err
 	}
+	if !cached {
+		c.setCached(call, apiURL.String(), response, cacheTTL(currentWeather.latestDateTime(),
+			DefaultCacheTTLCurrentWeather))
+	}
+	currentWeather.Location = c.reverseGeoLocation(ctx, call, latitude, longitude)
 
 	return currentWeather, nil
 }
 
 // CurrentWeatherByLocation returns the CurrentWeather values for the given location
-func (c *Client) CurrentWeatherByLocation(location string) (CurrentWeather, error) {
-	geoLocation, err := c.GetGeoLocationByName(location)
+func (c *Client) CurrentWeatherByLocation(location string, options ...CallOption) (CurrentWeather, error) {
+	return c.CurrentWeatherByLocationContext(context.Background(), location, options...)
+}
+
+// CurrentWeatherByLocationContext returns the CurrentWeather values for the given location
+// using the provided context for the API requests
+func (c *Client) CurrentWeatherByLocationContext(ctx context.Context, location string, options ...CallOption,
+) (CurrentWeather, error) {
+	ctx, cancel, _ := c.callContext(ctx, options)
+	defer cancel()
+
+	geoLocation, err := c.GetGeoLocationByNameContext(ctx, location, options...)
 	if err != nil {
 		return CurrentWeather{}, fmt.Errorf("failed too look up geolocation: %w", err)
 	}
-	return c.CurrentWeatherByCoordinates(geoLocation.Latitude, geoLocation.Longitude)
+	return c.CurrentWeatherByCoordinatesContext(ctx, geoLocation.Latitude, geoLocation.Longitude, options...)
+}
+
+// Units returns the UnitSystem of the values in the CurrentWeather
+func (cw CurrentWeather) Units() UnitSystem {
+	return UnitSystem(cw.UnitSystem)
+}
+
+// latestDateTime returns the timestamp of the most recent data point of the CurrentWeather
+func (cw CurrentWeather) latestDateTime() time.Time {
+	return latestDateTime(cw.Data.Temperature, cw.Data.Dewpoint, cw.Data.HumidityRelative,
+		cw.Data.PressureMSL, cw.Data.Precipitation, cw.Data.WindSpeed, cw.Data.CloudCoverage)
 }
 
 // CloudCoverage returns the cloud coverage data point as Coverage.
@@ -135,7 +177,7 @@ func (cw CurrentWeather) Dewpoint() Temperature {
 		dateTime: cw.Data.Dewpoint.DateTime,
 		name:     FieldDewpoint,
 		source:   SourceUnknown,
-		floatVal: cw.Data.Dewpoint.Value,
+		floatVal: cw.Units().celsius(cw.Data.Dewpoint.Value),
 	}
 	if cw.Data.Dewpoint.Source != nil {
 		temperature.source = StringToSource(*cw.Data.Dewpoint.Source)
@@ -205,7 +247,7 @@ func (cw CurrentWeather) Precipitation(timeSpan Timespan) Precipitation {
 		dateTime: apiFloat.DateTime,
 		name:     fieldName,
 		source:   SourceUnknown,
-		floatVal: apiFloat.Value,
+		floatVal: cw.Units().milliMeter(apiFloat.Value),
 	}
 	if apiFloat.Source != nil {
 		precipitation.source = StringToSource(*apiFloat.Source)
@@ -225,7 +267,7 @@ func (cw CurrentWeather) PressureMSL() Pressure {
 		dateTime: cw.Data.PressureMSL.DateTime,
 		name:     FieldPressureMSL,
 		source:   SourceUnknown,
-		floatVal: cw.Data.PressureMSL.Value,
+		floatVal: cw.Units().hectoPascal(cw.Data.PressureMSL.Value),
 	}
 	if cw.Data.PressureMSL.Source != nil {
 		pressure.source = StringToSource(*cw.Data.PressureMSL.Source)
@@ -245,7 +287,7 @@ func (cw CurrentWeather) PressureQFE() Pressure {
 		dateTime: cw.Data.PressureQFE.DateTime,
 		name:     FieldPressureQFE,
 		source:   SourceUnknown,
-		floatVal: cw.Data.PressureQFE.Value,
+		floatVal: cw.Units().hectoPascal(cw.Data.PressureQFE.Value),
 	}
 	if cw.Data.PressureQFE.Source != nil {
 		pressure.source = StringToSource(*cw.Data.PressureQFE.Source)
@@ -265,7 +307,7 @@ func (cw CurrentWeather) SnowAmount() Density {
 		dateTime: cw.Data.SnowAmount.DateTime,
 		name:     FieldSnowAmount,
 		source:   SourceUnknown,
-		floatVal: cw.Data.SnowAmount.Value,
+		floatVal: cw.Units().kilogramPerCubicMeter(cw.Data.SnowAmount.Value),
 	}
 	if cw.Data.SnowAmount.Source != nil {
 		density.source = StringToSource(*cw.Data.SnowAmount.Source)
@@ -285,7 +327,7 @@ func (cw CurrentWeather) SnowHeight() Height {
 		dateTime: cw.Data.SnowHeight.DateTime,
 		name:     FieldSnowHeight,
 		source:   SourceUnknown,
-		floatVal: cw.Data.SnowHeight.Value,
+		floatVal: cw.Units().meter(cw.Data.SnowHeight.Value),
 	}
 	if cw.Data.SnowHeight.Source != nil {
 		height.source = StringToSource(*cw.Data.SnowHeight.Source)
@@ -305,7 +347,7 @@ func (cw CurrentWeather) Temperature() Temperature {
 		dateTime: cw.Data.Temperature.DateTime,
 		name:     FieldTemperature,
 		source:   SourceUnknown,
-		floatVal: cw.Data.Temperature.Value,
+		floatVal: cw.Units().celsius(cw.Data.Temperature.Value),
 	}
 	if cw.Data.Temperature.Source != nil {
 		temperature.source = StringToSource(*cw.Data.Temperature.Source)
@@ -365,7 +407,7 @@ func (cw CurrentWeather) WindGust() Speed {
 		dateTime: cw.Data.WindGust.DateTime,
 		name:     FieldWindGust,
 		source:   SourceUnknown,
-		floatVal: cw.Data.WindGust.Value,
+		floatVal: cw.Units().meterPerSecond(cw.Data.WindGust.Value),
 	}
 	if cw.Data.WindGust.Source != nil {
 		speed.source = StringToSource(*cw.Data.WindGust.Source)
@@ -385,7 +427,7 @@ func (cw CurrentWeather) WindSpeed() Speed {
 		dateTime: cw.Data.WindSpeed.DateTime,
 		name:     FieldWindSpeed,
 		source:   SourceUnknown,
-		floatVal: cw.Data.WindSpeed.Value,
+		floatVal: cw.Units().meterPerSecond(cw.Data.WindSpeed.Value),
 	}
 	if cw.Data.WindSpeed.Source != nil {
 		speed.source = StringToSource(*cw.Data.WindSpeed.Source)
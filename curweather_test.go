@@ -5,8 +5,12 @@
 package meteologix
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -22,7 +26,7 @@ func TestClient_CurrentWeatherByCoordinates_Mock(t *testing.T) {
 	}{
 		{50.9833, 6.9833, "metric"},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -50,6 +54,37 @@ func TestClient_CurrentWeatherByCoordinates_Mock(t *testing.T) {
 	}
 }
 
+func TestClient_CurrentWeatherByCoordinatesContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"lat":50.9833,"lon":6.9833,"systemOfUnits":"metric","data":{}}`))
+	}))
+	defer server.Close()
+
+	c := New()
+	c.config.apiURL = server.URL
+	cw, err := c.CurrentWeatherByCoordinatesContext(context.Background(), 50.9833, 6.9833)
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinatesContext failed: %s", err)
+		return
+	}
+	if cw.UnitSystem != "metric" {
+		t.Errorf("CurrentWeatherByCoordinatesContext failed, expected unit system: %s, got: %s", "metric",
+			cw.UnitSystem)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.CurrentWeatherByCoordinatesContext(ctx, 50.9833, 6.9833)
+	if err == nil {
+		t.Errorf("CurrentWeatherByCoordinatesContext with canceled context was supposed to fail, but didn't")
+		return
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CurrentWeatherByCoordinatesContext was expected to fail with context.Canceled, got: %s", err)
+	}
+}
+
 func TestClient_CurrentWeatherByLocation(t *testing.T) {
 	tt := []struct {
 		// Location string
@@ -63,7 +98,7 @@ func TestClient_CurrentWeatherByLocation(t *testing.T) {
 	}{
 		{"Ehrenfeld, Germany", 50.9833, 6.9833, "metric"},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -92,7 +127,7 @@ func TestClient_CurrentWeatherByLocation(t *testing.T) {
 }
 
 func TestClient_CurrentWeatherByLocation_Fail(t *testing.T) {
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -126,7 +161,7 @@ func TestClient_CurrentWeatherByLocation_CloudCoverage(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -183,7 +218,7 @@ func TestClient_CurrentWeatherByLocation_Dewpoint(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -240,7 +275,7 @@ func TestClient_CurrentWeatherByLocation_HumidityRelative(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -289,7 +324,7 @@ func TestClient_CurrentWeatherByLocation_IsDay(t *testing.T) {
 		{"Berlin, Germany", true},
 		{"Neermoor, Germany", false},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -319,7 +354,7 @@ func TestClient_CurrentWeatherByLocation_PrecipitationCurrent(t *testing.T) {
 		{"Berlin, Germany", nil},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -368,7 +403,7 @@ func TestClient_CurrentWeatherByLocation_Precipitation10m(t *testing.T) {
 		{"Berlin, Germany", nil},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -425,7 +460,7 @@ func TestClient_CurrentWeatherByLocation_Precipitation1h(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -474,7 +509,7 @@ func TestClient_CurrentWeatherByLocation_Precipitation24h(t *testing.T) {
 		{"Berlin, Germany", nil},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -531,7 +566,7 @@ func TestClient_CurrentWeatherByLocation_PressureMSL(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -584,7 +619,7 @@ func TestClient_CurrentWeatherByLocation_PressureQFE(t *testing.T) {
 		{"Berlin, Germany", nil},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -641,7 +676,7 @@ func TestClient_CurrentWeatherByLocation_SnowAmount(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -702,7 +737,7 @@ func TestClient_CurrentWeatherByLocation_SnowHeight(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -799,7 +834,7 @@ func TestClient_CurrentWeatherByLocation_Temperature(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -856,7 +891,7 @@ func TestClient_CurrentWeatherByLocation_WeatherSymbol(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -925,7 +960,7 @@ func TestClient_CurrentWeatherByLocation_WindDirection(t *testing.T) {
 		}, "WbN", "West by North"},
 		{"Neermoor, Germany", nil, "", ""},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -990,7 +1025,7 @@ func TestClient_CurrentWeatherByLocation_WindGust(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -1047,7 +1082,7 @@ func TestClient_CurrentWeatherByLocation_WindSpeed(t *testing.T) {
 		}},
 		{"Neermoor, Germany", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "curweather"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
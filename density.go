@@ -10,6 +10,10 @@ import (
 	"time"
 )
 
+// MultiplierPoundsPerCubicFoot is the multiplier for converting the base unit (kg/m³) to
+// pounds per cubic foot
+const MultiplierPoundsPerCubicFoot = 0.06242796
+
 // Density is a type wrapper of WeatherData for holding density values in kg/m³ in WeatherData
 type Density WeatherData
 
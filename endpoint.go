@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"strings"
+)
+
+// List of API endpoint types
+const (
+	// EndpointAstronomy represents the astronomical information endpoint
+	EndpointAstronomy Endpoint = "astronomy"
+	// EndpointCurrentWeather represents the current weather endpoint
+	EndpointCurrentWeather Endpoint = "current"
+	// EndpointForecast represents the weather forecast endpoint
+	EndpointForecast Endpoint = "forecast"
+	// EndpointGeocode represents the OSM Nominatim geocoding endpoint
+	EndpointGeocode Endpoint = "geocode"
+	// EndpointObservation represents the station observation endpoint
+	EndpointObservation Endpoint = "observation"
+	// EndpointReverseGeocode represents the OSM Nominatim reverse geocoding endpoint
+	EndpointReverseGeocode Endpoint = "reverse_geocode"
+	// EndpointStationSearch represents the station search endpoint
+	EndpointStationSearch Endpoint = "station_search"
+	// EndpointUnknown represents any URL that does not belong to a known endpoint
+	EndpointUnknown Endpoint = "unknown"
+)
+
+// Endpoint is a type wrapper for a string and represents the type of API endpoint
+// that is queried by the HTTPClient
+type Endpoint string
+
+// String satisfies the fmt.Stringer interface for the Endpoint type
+func (e Endpoint) String() string {
+	return string(e)
+}
+
+// endpoint returns the Endpoint type for the given URL, based on the configured API
+// and geocoder base URLs
+func (hc *HTTPClient) endpoint(url string) Endpoint {
+	if strings.HasPrefix(url, hc.geocoderURL+"/reverse") {
+		return EndpointReverseGeocode
+	}
+	if strings.HasPrefix(url, hc.geocoderURL+"/") {
+		return EndpointGeocode
+	}
+	if !strings.HasPrefix(url, hc.apiURL+"/") {
+		return EndpointUnknown
+	}
+	path := strings.TrimPrefix(url, hc.apiURL)
+	switch {
+	case strings.HasPrefix(path, "/current/"):
+		return EndpointCurrentWeather
+	case strings.HasPrefix(path, "/forecast/"):
+		return EndpointForecast
+	case strings.HasPrefix(path, "/station/search/"):
+		return EndpointStationSearch
+	case strings.HasPrefix(path, "/station/") && strings.Contains(path, "/observations/"):
+		return EndpointObservation
+	case strings.HasPrefix(path, "/tools/astronomy/"):
+		return EndpointAstronomy
+	default:
+		return EndpointUnknown
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"testing"
+)
+
+func TestHTTPClient_endpoint(t *testing.T) {
+	tt := []struct {
+		// URL
+		u string
+		// Expected Endpoint
+		e Endpoint
+	}{
+		{APIBaseURL + "/current/50.9/6.9?units=metric", EndpointCurrentWeather},
+		{APIBaseURL + "/forecast/50.9/6.9/standard/1h", EndpointForecast},
+		{APIBaseURL + "/station/search/50.9/6.9?radius=10", EndpointStationSearch},
+		{APIBaseURL + "/station/H744/observations/latest", EndpointObservation},
+		{APIBaseURL + "/tools/astronomy/50.9/6.9", EndpointAstronomy},
+		{APIBaseURL + "/unknown", EndpointUnknown},
+		{OSMNominatimURL + "?q=Cologne", EndpointGeocode},
+		{OSMNominatimBaseURL + "/reverse?lat=50.9&lon=6.9", EndpointReverseGeocode},
+		{"https://example.com/current/1/1", EndpointUnknown},
+	}
+	c := New()
+	for _, tc := range tt {
+		t.Run(tc.e.String(), func(t *testing.T) {
+			if e := c.httpClient.endpoint(tc.u); e != tc.e {
+				t.Errorf("endpoint for URL %s failed, expected: %s, got: %s", tc.u, tc.e, e)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -87,6 +87,7 @@ type WeatherForecastDatapoint struct {
 	pressureMSL   NilFloat64
 	sunhours      NilFloat64
 	temperature   float64
+	unitSystem    UnitSystem
 	weatherSymbol NilString
 	winddirection NilFloat64
 	windgust      NilFloat64
@@ -96,8 +97,20 @@ type WeatherForecastDatapoint struct {
 
 // ForecastByCoordinates returns the WeatherForecast values for the given coordinates
 func (c *Client) ForecastByCoordinates(latitude, longitude float64, timespan Timespan,
-	details ForecastDetails,
+	details ForecastDetails, options ...CallOption,
 ) (WeatherForecast, error) {
+	return c.ForecastByCoordinatesContext(context.Background(), latitude, longitude, timespan, details,
+		options...)
+}
+
+// ForecastByCoordinatesContext returns the WeatherForecast values for the given coordinates
+// using the provided context for the API request
+func (c *Client) ForecastByCoordinatesContext(ctx context.Context, latitude, longitude float64,
+	timespan Timespan, details ForecastDetails, options ...CallOption,
+) (WeatherForecast, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
 	var forecast WeatherForecast
 	var steps string
 	switch timespan {
@@ -115,16 +128,19 @@ func (c *Client) ForecastByCoordinates(latitude, longitude float64, timespan Tim
 		return forecast, fmt.Errorf("failed to parse weather forecast URL: %w", err)
 	}
 	queryString := apiURL.Query()
-	queryString.Add("units", "metric")
+	queryString.Add("units", string(call.unitSystem))
 	apiURL.RawQuery = queryString.Encode()
 
-	response, err := c.httpClient.Get(apiURL.String())
+	response, cached, err := c.getCached(ctx, call, apiURL.String())
 	if err != nil {
 		return forecast, fmt.Errorf("API request failed: %w", err)
 	}
 
-	if err = json.Unmarshal(response, &forecast); err != nil {
-		return forecast, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = c.httpClient.unmarshalResponse(ctx, apiURL.String(), response, &forecast); err != nil {
+		return forecast, err
+	}
+	if !cached {
+		c.setCached(call, apiURL.String(), response, cacheTTL(forecast.Run, DefaultCacheTTLForecast))
 	}
 
 	return forecast, nil
@@ -132,13 +148,30 @@ func (c *Client) ForecastByCoordinates(latitude, longitude float64, timespan Tim
 
 // ForecastByLocation returns the WeatherForecast values for the given location
 func (c *Client) ForecastByLocation(location string, timesteps Timespan,
-	details ForecastDetails,
+	details ForecastDetails, options ...CallOption,
 ) (WeatherForecast, error) {
-	geoLocation, err := c.GetGeoLocationByName(location)
+	return c.ForecastByLocationContext(context.Background(), location, timesteps, details, options...)
+}
+
+// ForecastByLocationContext returns the WeatherForecast values for the given location
+// using the provided context for the API requests
+func (c *Client) ForecastByLocationContext(ctx context.Context, location string, timesteps Timespan,
+	details ForecastDetails, options ...CallOption,
+) (WeatherForecast, error) {
+	ctx, cancel, _ := c.callContext(ctx, options)
+	defer cancel()
+
+	geoLocation, err := c.GetGeoLocationByNameContext(ctx, location, options...)
 	if err != nil {
 		return WeatherForecast{}, fmt.Errorf("failed too look up geolocation: %w", err)
 	}
-	return c.ForecastByCoordinates(geoLocation.Latitude, geoLocation.Longitude, timesteps, details)
+	return c.ForecastByCoordinatesContext(ctx, geoLocation.Latitude, geoLocation.Longitude, timesteps, details,
+		options...)
+}
+
+// Units returns the UnitSystem of the values in the WeatherForecast
+func (wf WeatherForecast) Units() UnitSystem {
+	return UnitSystem(wf.UnitSystem)
 }
 
 // At returns the WeatherForecastDatapoint for the specified timestamp. It will try to find the closest datapoint
@@ -149,14 +182,14 @@ func (wf WeatherForecast) At(timestamp time.Time) WeatherForecastDatapoint {
 	if datapoint == nil {
 		return WeatherForecastDatapoint{}
 	}
-	return newWeatherForecastDataPoint(*datapoint)
+	return newWeatherForecastDataPoint(*datapoint, wf.Units())
 }
 
 // All returns a slice of WeatherForecastDatapoint representing all forecasted data points.
 func (wf WeatherForecast) All() []WeatherForecastDatapoint {
 	datapoints := make([]WeatherForecastDatapoint, 0)
 	for _, data := range wf.Data {
-		datapoint := newWeatherForecastDataPoint(data)
+		datapoint := newWeatherForecastDataPoint(data, wf.Units())
 		datapoints = append(datapoints, datapoint)
 	}
 	return datapoints
@@ -196,7 +229,7 @@ func (dp WeatherForecastDatapoint) Dewpoint() Temperature {
 		dateTime: dp.dateTime,
 		name:     FieldDewpoint,
 		source:   SourceForecast,
-		floatVal: dp.dewpoint.Get(),
+		floatVal: dp.unitSystem.celsius(dp.dewpoint.Get()),
 	}
 	return temperature
 }
@@ -230,7 +263,7 @@ func (dp WeatherForecastDatapoint) PressureMSL() Pressure {
 		dateTime: dp.dateTime,
 		name:     FieldPressureMSL,
 		source:   SourceForecast,
-		floatVal: dp.pressureMSL.Get(),
+		floatVal: dp.unitSystem.hectoPascal(dp.pressureMSL.Get()),
 	}
 	return pressure
 }
@@ -258,7 +291,7 @@ func (dp WeatherForecastDatapoint) Temperature() Temperature {
 		dateTime: dp.DateTime(),
 		name:     FieldTemperature,
 		source:   SourceForecast,
-		floatVal: dp.temperature,
+		floatVal: dp.unitSystem.celsius(dp.temperature),
 	}
 }
 
@@ -308,7 +341,7 @@ func (dp WeatherForecastDatapoint) WindGust() Speed {
 		dateTime: dp.dateTime,
 		name:     FieldWindGust,
 		source:   SourceForecast,
-		floatVal: dp.windgust.Get(),
+		floatVal: dp.unitSystem.meterPerSecond(dp.windgust.Get()),
 	}
 	return speed
 }
@@ -325,7 +358,7 @@ func (dp WeatherForecastDatapoint) WindGust3h() Speed {
 		dateTime: dp.dateTime,
 		name:     FieldWindGust3h,
 		source:   SourceForecast,
-		floatVal: dp.windgust3h.Get(),
+		floatVal: dp.unitSystem.meterPerSecond(dp.windgust3h.Get()),
 	}
 	return speed
 }
@@ -342,7 +375,7 @@ func (dp WeatherForecastDatapoint) WindSpeed() Speed {
 		dateTime: dp.dateTime,
 		name:     FieldWindSpeed,
 		source:   SourceForecast,
-		floatVal: dp.windspeed.Get(),
+		floatVal: dp.unitSystem.meterPerSecond(dp.windspeed.Get()),
 	}
 	return speed
 }
@@ -371,8 +404,9 @@ func findClosestForecast(items []APIWeatherForecastData, target time.Time) *APIW
 
 // newWeatherForecastDataPoint creates a new WeatherForecastDatapoint from the provided APIWeatherForecastData.
 // It extracts the necessary data from the APIWeatherForecastData and sets them in the WeatherForecastDatapoint
-// structure. The new WeatherForecastDatapoint is then returned.
-func newWeatherForecastDataPoint(data APIWeatherForecastData) WeatherForecastDatapoint {
+// structure, together with the UnitSystem the data was returned in. The new WeatherForecastDatapoint is then
+// returned.
+func newWeatherForecastDataPoint(data APIWeatherForecastData, unitSystem UnitSystem) WeatherForecastDatapoint {
 	return WeatherForecastDatapoint{
 		cloudCoverage: data.CloudCoverage,
 		dateTime:      data.DateTime,
@@ -382,6 +416,7 @@ func newWeatherForecastDataPoint(data APIWeatherForecastData) WeatherForecastDat
 		pressureMSL:   data.PressureMSL,
 		sunhours:      data.SunHours,
 		temperature:   data.Temperature,
+		unitSystem:    unitSystem,
 		weatherSymbol: data.WeatherSymbol,
 		winddirection: data.WindDirection,
 		windgust:      data.WindGust,
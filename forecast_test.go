@@ -23,7 +23,7 @@ func TestWeatherForecast_All(t *testing.T) {
 		{"6h Standard", 50.9586327, 6.9685969, Timespan6Hours, ForecastDetailStandard, 38},
 		{"1h Advanced", 50.9586327, 6.9685969, Timespan1Hour, ForecastDetailAdvanced, 24},
 	}
-	client := New(withMockAPI())
+	client := New(withMockAPI(), withCassette(t, "forecast"))
 	if client == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -79,7 +79,7 @@ func TestWeatherForecast_At(t *testing.T) {
 			time.Date(2024, 8, 30, 2, 0, 0, 0, time.UTC),
 		},
 	}
-	client := New(withMockAPI())
+	client := New(withMockAPI(), withCassette(t, "forecast"))
 	if client == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+// Package gazetteer provides an offline meteologix.Geocoder based on an embedded, compact
+// gazetteer of major world cities. It can be used with meteologix.WithGeocoder, so that
+// GetGeoLocationsByName and all *ByLocation methods of the meteologix Client work without
+// access to the OSM Nominatim API.
+//
+// The embedded data holds all German cities with at least 100,000 inhabitants, as Germany
+// is the main coverage area of the Meteologix API, and the capitals and largest cities of
+// the other countries of the world. Smaller places are not included, so for a complete
+// coverage the OSM Nominatim API or a custom meteologix.Geocoder is needed.
+//
+// City names are matched case-insensitive, with diacritics folded (e.g. "koln" matches
+// "Köln") and with a configurable tolerance for typos. A name can be qualified with a
+// state, country or ISO country code, separated by commas (e.g. "Springfield, Illinois"
+// or "Cordoba, AR").
+package gazetteer
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wneessen/go-meteologix"
+)
+
+// List of population thresholds for WithMinPopulation
+const (
+	// Population100k includes all cities with at least 100,000 inhabitants
+	Population100k = 100_000
+	// Population500k includes all cities with at least 500,000 inhabitants
+	Population500k = 500_000
+	// Population1M includes all cities with at least 1,000,000 inhabitants
+	Population1M = 1_000_000
+	// Population5M includes all cities with at least 5,000,000 inhabitants
+	Population5M = 5_000_000
+)
+
+// ErrCityNotFound is returned if no city of the Gazetteer matches the requested name. It
+// wraps meteologix.ErrCityNotFound, so that it can be handled like the errors of the other
+// meteologix.Geocoder implementations
+var ErrCityNotFound = fmt.Errorf("requested city not found in gazetteer: %w", meteologix.ErrCityNotFound)
+
+// DefaultMaxDistance is the default maximum number of typos (edit distance) that a name
+// may differ from a city name to still match
+const DefaultMaxDistance = 2
+
+// importancePopulation is the population at which a city is assigned the maximum
+// Importance of 1
+const importancePopulation = 1e8
+
+// citiesCSV holds the embedded gazetteer data
+//
+//go:embed cities.csv
+var citiesCSV []byte
+
+// embeddedCities holds the parsed cities of the embedded gazetteer data
+var embeddedCities = sync.OnceValues(func() ([]City, error) {
+	return parseCities(bytes.NewReader(citiesCSV))
+})
+
+// City represents a city of the gazetteer
+type City struct {
+	// AlternateNames holds alternate names of the City (e.g. exonyms like "Cologne")
+	AlternateNames []string
+	// Country is the name of the country of the City
+	Country string
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country of the City
+	CountryCode string
+	// Latitude represents the GPS Latitude coordinates of the City
+	Latitude float64
+	// Longitude represents the GPS Longitude coordinates of the City
+	Longitude float64
+	// Name is the local name of the City
+	Name string
+	// Population is the approximate number of inhabitants of the City
+	Population int
+	// State is the name of the state (the top-level administrative area) of the City
+	State string
+}
+
+// Gazetteer is an offline meteologix.Geocoder based on the embedded gazetteer data. It is
+// safe for concurrent use.
+type Gazetteer struct {
+	// cities holds the cities of the Gazetteer that match the configured thresholds
+	cities []indexedCity
+	// countryCodes holds the (optional) ISO country codes the cities are restricted to
+	countryCodes []string
+	// maxDistance is the maximum edit distance of a fuzzy match
+	maxDistance int
+	// minPopulation is the minimum population of the cities
+	minPopulation int
+}
+
+// Option represents a function that is used for setting/overriding Gazetteer options
+type Option func(*Gazetteer)
+
+// indexedCity is a City with its normalized names and qualifiers
+type indexedCity struct {
+	City
+	// names holds the normalized name and alternate names of the City
+	names []string
+	// qualifiers holds the normalized state, country and country code of the City
+	qualifiers []string
+}
+
+// New returns a new Gazetteer based on the embedded gazetteer data. An error is returned
+// if the embedded gazetteer data is invalid
+func New(options ...Option) (*Gazetteer, error) {
+	gazetteer := &Gazetteer{maxDistance: DefaultMaxDistance}
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(gazetteer)
+	}
+
+	cities, err := embeddedCities()
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded gazetteer data: %w", err)
+	}
+	for _, city := range cities {
+		if city.Population < gazetteer.minPopulation {
+			continue
+		}
+		if len(gazetteer.countryCodes) > 0 && !contains(gazetteer.countryCodes, city.CountryCode) {
+			continue
+		}
+		gazetteer.cities = append(gazetteer.cities, newIndexedCity(city))
+	}
+	return gazetteer, nil
+}
+
+// MustNew is like New but panics if the embedded gazetteer data is invalid. It simplifies
+// the initialization of a Gazetteer, e.g. in a call of meteologix.WithGeocoder
+func MustNew(options ...Option) *Gazetteer {
+	gazetteer, err := New(options...)
+	if err != nil {
+		panic(err)
+	}
+	return gazetteer
+}
+
+// WithCountryCodes restricts the Gazetteer to the cities of the given countries (ISO 3166-1
+// alpha-2 codes, e.g. "de" or "us")
+func WithCountryCodes(countryCodes ...string) Option {
+	var codes []string
+	for _, countryCode := range countryCodes {
+		if countryCode = strings.ToUpper(strings.TrimSpace(countryCode)); countryCode != "" {
+			codes = append(codes, countryCode)
+		}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return func(gazetteer *Gazetteer) {
+		gazetteer.countryCodes = codes
+	}
+}
+
+// WithMaxDistance sets the maximum number of typos (edit distance) that a name may differ
+// from a city name to still match. Short names allow fewer typos. A distance of 0 disables
+// fuzzy matching
+func WithMaxDistance(distance int) Option {
+	if distance < 0 {
+		return nil
+	}
+	return func(gazetteer *Gazetteer) {
+		gazetteer.maxDistance = distance
+	}
+}
+
+// WithMinPopulation restricts the Gazetteer to cities with at least the given number of
+// inhabitants (see Population100k, Population500k, Population1M and Population5M)
+func WithMinPopulation(population int) Option {
+	if population < 0 {
+		return nil
+	}
+	return func(gazetteer *Gazetteer) {
+		gazetteer.minPopulation = population
+	}
+}
+
+// Len returns the number of cities in the Gazetteer
+func (g *Gazetteer) Len() int {
+	return len(g.cities)
+}
+
+// GeoLocationsByName satisfies the meteologix.Geocoder interface for the Gazetteer type.
+//
+// Only the cities with the closest match for the given name are returned, sorted by
+// Importance (based on the population) with the highest importance as first entry. If no
+// city matches the name, ErrCityNotFound is returned
+func (g *Gazetteer) GeoLocationsByName(ctx context.Context, name string) ([]meteologix.GeoLocation, error) {
+	locations := make([]meteologix.GeoLocation, 0)
+	if err := ctx.Err(); err != nil {
+		return locations, err
+	}
+
+	parts := strings.Split(name, ",")
+	query := normalize(parts[0])
+	if query == "" {
+		return locations, ErrCityNotFound
+	}
+	qualifiers := make([]string, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if qualifier := normalize(part); qualifier != "" {
+			qualifiers = append(qualifiers, qualifier)
+		}
+	}
+
+	var matches []indexedCity
+	allowed := allowedDistance(query, g.maxDistance)
+	bestDistance := allowed + 1
+	for _, city := range g.cities {
+		if !city.qualifiedBy(qualifiers) {
+			continue
+		}
+		distance := city.distance(query, bestDistance+1)
+		switch {
+		case distance > allowed:
+		case distance < bestDistance:
+			bestDistance = distance
+			matches = append(matches[:0], city)
+		case distance == bestDistance:
+			matches = append(matches, city)
+		}
+	}
+	if len(matches) == 0 {
+		return locations, ErrCityNotFound
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Population > matches[j].Population })
+
+	for _, city := range matches {
+		locations = append(locations, city.geoLocation())
+	}
+	return locations, nil
+}
+
+// newIndexedCity returns the indexedCity for the given City
+func newIndexedCity(city City) indexedCity {
+	indexed := indexedCity{City: city}
+	for _, name := range append([]string{city.Name}, city.AlternateNames...) {
+		indexed.names = append(indexed.names, normalize(name))
+	}
+	indexed.qualifiers = []string{normalize(city.State), normalize(city.Country), normalize(city.CountryCode)}
+	return indexed
+}
+
+// qualifiedBy returns true if all given qualifiers match the state, country or country code
+// of the indexedCity
+func (c indexedCity) qualifiedBy(qualifiers []string) bool {
+	for _, qualifier := range qualifiers {
+		if !contains(c.qualifiers, qualifier) {
+			return false
+		}
+	}
+	return true
+}
+
+// distance returns the smallest edit distance between the given query and the names of
+// the indexedCity. Distances of limit or more are not computed exactly and returned as limit
+func (c indexedCity) distance(query string, limit int) int {
+	best := limit
+	for _, name := range c.names {
+		if name == query {
+			return 0
+		}
+		if distance := levenshtein(query, name, best); distance < best {
+			best = distance
+		}
+	}
+	return best
+}
+
+// geoLocation returns the meteologix.GeoLocation of the indexedCity
+func (c indexedCity) geoLocation() meteologix.GeoLocation {
+	nameParts := []string{c.Name}
+	if c.State != "" && c.State != c.Name {
+		nameParts = append(nameParts, c.State)
+	}
+	if c.Country != "" && c.Country != c.Name {
+		nameParts = append(nameParts, c.Country)
+	}
+	return meteologix.GeoLocation{
+		Address: meteologix.GeoAddress{
+			City:        c.Name,
+			Country:     c.Country,
+			CountryCode: strings.ToLower(c.CountryCode),
+			State:       c.State,
+		},
+		Class:           "place",
+		Importance:      math.Min(math.Log10(float64(c.Population))/math.Log10(importancePopulation), 1),
+		Latitude:        c.Latitude,
+		LatitudeString:  strconv.FormatFloat(c.Latitude, 'f', -1, 64),
+		Longitude:       c.Longitude,
+		LongitudeString: strconv.FormatFloat(c.Longitude, 'f', -1, 64),
+		Name:            strings.Join(nameParts, ", "),
+		Type:            "city",
+	}
+}
+
+// parseCities parses the cities of the gazetteer data in the given io.Reader
+func parseCities(reader io.Reader) ([]City, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = 8
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gazetteer data: %w", err)
+	}
+	if len(records) < 1 {
+		return nil, fmt.Errorf("gazetteer data holds no header")
+	}
+
+	cities := make([]City, 0, len(records)-1)
+	for _, record := range records[1:] {
+		city := City{Name: record[0], State: record[2], Country: record[3], CountryCode: record[4]}
+		if record[1] != "" {
+			city.AlternateNames = strings.Split(record[1], "|")
+		}
+		if city.Latitude, err = strconv.ParseFloat(record[5], 64); err != nil {
+			return nil, fmt.Errorf("failed to parse latitude of %s: %w", city.Name, err)
+		}
+		if city.Longitude, err = strconv.ParseFloat(record[6], 64); err != nil {
+			return nil, fmt.Errorf("failed to parse longitude of %s: %w", city.Name, err)
+		}
+		if city.Population, err = strconv.Atoi(record[7]); err != nil {
+			return nil, fmt.Errorf("failed to parse population of %s: %w", city.Name, err)
+		}
+		if err = city.validate(); err != nil {
+			return nil, fmt.Errorf("invalid data of %s: %w", city.Name, err)
+		}
+		cities = append(cities, city)
+	}
+	return cities, nil
+}
+
+// validate returns an error if the values of the City are out of range
+func (c City) validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("name is empty")
+	case len(c.CountryCode) != 2:
+		return fmt.Errorf("invalid country code: %q", c.CountryCode)
+	case c.Latitude < -90 || c.Latitude > 90:
+		return fmt.Errorf("latitude out of range: %f", c.Latitude)
+	case c.Longitude < -180 || c.Longitude > 180:
+		return fmt.Errorf("longitude out of range: %f", c.Longitude)
+	case c.Population < 1:
+		return fmt.Errorf("population out of range: %d", c.Population)
+	}
+	return nil
+}
+
+// contains returns true if the given value is part of the given slice
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package gazetteer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wneessen/go-meteologix"
+	"github.com/wneessen/go-meteologix/meteologixtest"
+)
+
+func TestGazetteer_GeoLocationsByName(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Gazetteer options
+		o []Option
+		// Name to look up
+		q string
+		// Expected number of results
+		c int
+		// Expected name of the first result
+		e string
+		// Should fail
+		sf bool
+	}{
+		{"Exact name", nil, "Berlin", 1, "Berlin, Germany", false},
+		{"Alternate name", nil, "Cologne", 1, "Köln, Nordrhein-Westfalen, Germany", false},
+		{"Folded diacritics", nil, "koln", 1, "Köln, Nordrhein-Westfalen, Germany", false},
+		{"Case and whitespace", nil, "  NEW   york ", 1, "New York, United States", false},
+		{"Typo", nil, "Hamburk", 1, "Hamburg, Germany", false},
+		{"Two typos", nil, "Duesseldurv", 1, "Düsseldorf, Nordrhein-Westfalen, Germany", false},
+		{"Sorted by population", nil, "Springfield", 3, "Springfield, Missouri, United States", false},
+		{"Qualified by state", nil, "Springfield, Illinois", 1, "Springfield, Illinois, United States", false},
+		{"Qualified by country code", nil, "Cordoba, AR", 1, "Córdoba, Argentina", false},
+		{"Qualified by country", nil, "Portland, United States", 2, "Portland, Oregon, United States", false},
+		{
+			"Min population", []Option{WithMinPopulation(Population100k)}, "Portland", 1,
+			"Portland, Oregon, United States", false,
+		},
+		{"Country codes", []Option{WithCountryCodes("es")}, "Cordoba", 1, "Córdoba, Andalusia, Spain", false},
+		{"Fuzzy matching disabled", []Option{WithMaxDistance(0)}, "Hamburk", 0, "", true},
+		{"Short names allow no typos", nil, "Ulx", 0, "", true},
+		{"Unknown name", nil, "Gotham", 0, "", true},
+		{"Wrong qualifier", nil, "Berlin, FR", 0, "", true},
+		{"Empty name", nil, " ", 0, "", true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			var geocoder meteologix.Geocoder = MustNew(tc.o...)
+			l, err := geocoder.GeoLocationsByName(context.Background(), tc.q)
+			if tc.sf {
+				if !errors.Is(err, ErrCityNotFound) || !errors.Is(err, meteologix.ErrCityNotFound) {
+					t.Errorf("GeoLocationsByName was supposed to fail with ErrCityNotFound, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GeoLocationsByName failed: %s", err)
+				return
+			}
+			if len(l) != tc.c {
+				t.Errorf("GeoLocationsByName failed, expected %d results, got: %d", tc.c, len(l))
+				return
+			}
+			if l[0].Name != tc.e {
+				t.Errorf("GeoLocationsByName failed, expected name: %s, got: %s", tc.e, l[0].Name)
+			}
+			for i := 1; i < len(l); i++ {
+				if l[i].Importance > l[i-1].Importance {
+					t.Errorf("GeoLocationsByName failed, expected results sorted by importance")
+				}
+			}
+		})
+	}
+}
+
+func TestGazetteer_GeoLocationsByName_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := MustNew().GeoLocationsByName(ctx, "Berlin"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GeoLocationsByName was supposed to fail with context.Canceled, got: %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	gazetteer, err := New()
+	if err != nil {
+		t.Errorf("New failed: %s", err)
+		return
+	}
+	all := gazetteer.Len()
+	if all < 400 {
+		t.Errorf("New failed, expected at least 400 cities, got: %d", all)
+	}
+	large := MustNew(WithMinPopulation(Population5M)).Len()
+	if large < 1 || large >= all {
+		t.Errorf("New with WithMinPopulation failed, expected 1 to %d cities, got: %d", all-1, large)
+	}
+	if MustNew(WithMinPopulation(-1), WithMaxDistance(-1), WithCountryCodes(" ")).Len() != all {
+		t.Errorf("New failed, expected invalid options to be ignored")
+	}
+	german := MustNew(WithCountryCodes("de")).Len()
+	if german*4 > all {
+		t.Errorf("New failed, expected less than a quarter of the cities in Germany, got: %d of %d", german,
+			all)
+	}
+}
+
+func TestEmbeddedCities(t *testing.T) {
+	cities, err := embeddedCities()
+	if err != nil {
+		t.Errorf("embedded gazetteer data is invalid: %s", err)
+		return
+	}
+	seen := make(map[string]bool, len(cities))
+	for _, city := range cities {
+		key := city.Name + "," + city.State + "," + city.CountryCode
+		if seen[key] {
+			t.Errorf("embedded gazetteer data holds duplicate city: %s", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestParseCities(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// CSV data
+		d string
+		// Should fail
+		sf bool
+	}{
+		{"Valid", "# comment\nname,alternates,state,country,country_code,latitude,longitude,population\n" +
+			"Köln,Cologne|Koeln,NRW,Germany,DE,50.9375,6.9603,1084831\n", false},
+		{"No header", "", true},
+		{"Missing field", "header,a,b,c,d,e,f,g\nKöln,,NRW,Germany,DE,50.9375,6.9603\n", true},
+		{"Invalid latitude", "header,a,b,c,d,e,f,g\nKöln,,NRW,Germany,DE,north,6.9603,1084831\n", true},
+		{"Invalid population", "header,a,b,c,d,e,f,g\nKöln,,NRW,Germany,DE,50.9375,6.9603,many\n", true},
+		{"Latitude out of range", "header,a,b,c,d,e,f,g\nKöln,,NRW,Germany,DE,509.375,6.9603,1084831\n", true},
+		{"Invalid country code", "header,a,b,c,d,e,f,g\nKöln,,NRW,Germany,DEU,50.9375,6.9603,1084831\n", true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			cities, err := parseCities(strings.NewReader(tc.d))
+			if tc.sf {
+				if err == nil {
+					t.Errorf("parseCities was supposed to fail, but didn't")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("parseCities failed: %s", err)
+				return
+			}
+			if len(cities) != 1 || len(cities[0].AlternateNames) != 2 || cities[0].Population != 1084831 {
+				t.Errorf("parseCities failed, unexpected result: %+v", cities)
+			}
+		})
+	}
+}
+
+func TestGazetteer_WithClient(t *testing.T) {
+	server := meteologixtest.NewTestServer(t)
+	c := meteologix.New(append(server.ClientOptions(), meteologix.WithGeocoder(MustNew()))...)
+	cw, err := c.CurrentWeatherByLocation("Muenchen")
+	if err != nil {
+		t.Errorf("CurrentWeatherByLocation failed: %s", err)
+		return
+	}
+	if cw.Latitude != 48.1372 || cw.Longitude != 11.5755 {
+		t.Errorf("CurrentWeatherByLocation failed, unexpected coordinates: %f/%f", cw.Latitude, cw.Longitude)
+	}
+	server.AssertRequestCount(t, meteologix.EndpointGeocode, 0)
+}
+
+func TestLevenshtein(t *testing.T) {
+	tt := []struct {
+		// First string
+		a string
+		// Second string
+		b string
+		// Limit
+		l int
+		// Expected distance
+		e int
+	}{
+		{"hamburg", "hamburg", 3, 0},
+		{"hamburk", "hamburg", 3, 1},
+		{"hambrug", "hamburg", 3, 2},
+		{"berlin", "bern", 3, 2},
+		{"berlin", "paris", 3, 3},
+		{"a", "abcdef", 3, 3},
+	}
+	for _, tc := range tt {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			if d := levenshtein(tc.a, tc.b, tc.l); d != tc.e {
+				t.Errorf("levenshtein failed, expected: %d, got: %d", tc.e, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package gazetteer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// foldedRunes maps letters with diacritics to their ASCII representation
+var foldedRunes = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'ł': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ș': "s", 'ß': "ss", 'ť': "t", 'ţ': "t", 'ț': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+	'ạ': "a", 'ả': "a", 'ấ': "a", 'ầ': "a", 'ậ': "a", 'ồ': "o", 'ố': "o", 'ộ': "o", 'ơ': "o",
+	'ư': "u", 'ứ': "u", 'ừ': "u", 'ự': "u",
+}
+
+// normalize returns the given name in lower case and with diacritics folded. Apostrophes and
+// dots are removed, all other characters except letters and digits separate words. Words
+// are separated by a single space
+func normalize(name string) string {
+	var builder strings.Builder
+	for _, char := range strings.ToLower(name) {
+		if folded, ok := foldedRunes[char]; ok {
+			builder.WriteString(folded)
+			continue
+		}
+		switch {
+		case char == '\'' || char == '.' || unicode.Is(unicode.Mn, char):
+		case unicode.IsLetter(char) || unicode.IsDigit(char):
+			builder.WriteRune(char)
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// allowedDistance returns the maximum edit distance for a fuzzy match of the given
+// normalized query. Short queries allow fewer typos than long queries
+func allowedDistance(query string, maxDistance int) int {
+	allowed := utf8.RuneCountInString(query) / 4
+	if allowed > maxDistance {
+		return maxDistance
+	}
+	return allowed
+}
+
+// levenshtein returns the edit distance between the given strings. If the distance is
+// limit or more, limit is returned without computing the exact distance
+func levenshtein(a, b string, limit int) int {
+	source, target := []rune(a), []rune(b)
+	if difference := len(source) - len(target); difference >= limit || -difference >= limit {
+		return limit
+	}
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		rowMinimum := current[0]
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMinimum = min(rowMinimum, current[j])
+		}
+		if rowMinimum >= limit {
+			return limit
+		}
+		previous, current = current, previous
+	}
+	return min(previous[len(target)], limit)
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultGeocodeCacheTTL is the default time geocoding results are cached. Locations of
+// places almost never change.
+const DefaultGeocodeCacheTTL = time.Hour * 24 * 30
+
+// GeocodeCache caches the results of geolocation lookups by name, keyed by the normalized
+// name, the language and the GeoSearchOptions of the lookup. Optionally, the cached results
+// can be persisted in a JSON file, so that they survive restarts of the application.
+//
+// Write errors of the JSON file are ignored and result in the results not being persisted.
+type GeocodeCache struct {
+	// entries maps the cache keys to the geocodeCacheEntry values
+	entries map[string]geocodeCacheEntry
+	// mutex protects the cache
+	mutex sync.RWMutex
+	// path is the path of the JSON file the cache is persisted in. If empty, the cache is
+	// not persisted
+	path string
+	// ttl is the time-to-live of the cached results
+	ttl time.Duration
+}
+
+// geocodeCacheEntry represents a single entry of the GeocodeCache
+type geocodeCacheEntry struct {
+	// Expires is the time when the geocodeCacheEntry expires
+	Expires time.Time `json:"expires"`
+	// Locations holds the cached GeoLocation results
+	Locations []GeoLocation `json:"locations"`
+}
+
+// NewGeocodeCache returns a new in-memory GeocodeCache that caches results for the given
+// time-to-live duration. If the ttl is not positive, DefaultGeocodeCacheTTL is used
+func NewGeocodeCache(ttl time.Duration) *GeocodeCache {
+	if ttl <= 0 {
+		ttl = DefaultGeocodeCacheTTL
+	}
+	return &GeocodeCache{entries: make(map[string]geocodeCacheEntry), ttl: ttl}
+}
+
+// NewGeocodeFileCache returns a new GeocodeCache that is persisted in the JSON file at the
+// given path. Non-expired results of an existing file are loaded. The directory of the file
+// is created, if it does not exist yet
+func NewGeocodeFileCache(path string, ttl time.Duration) (*GeocodeCache, error) {
+	cache := NewGeocodeCache(ttl)
+	cache.path = path
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create geocode cache directory: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read geocode cache file: %w", err)
+	}
+	var entries map[string]geocodeCacheEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode geocode cache file: %w", err)
+	}
+	now := time.Now()
+	for key, entry := range entries {
+		if now.Before(entry.Expires) {
+			cache.entries[key] = entry
+		}
+	}
+	return cache, nil
+}
+
+// Len returns the number of entries in the GeocodeCache, including expired entries that
+// have not been evicted yet
+func (g *GeocodeCache) Len() int {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return len(g.entries)
+}
+
+// get returns the cached GeoLocation results for the given key. If the key is not present
+// or the results are expired, false is returned
+func (g *GeocodeCache) get(key string) ([]GeoLocation, bool) {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	entry, ok := g.entries[key]
+	if !ok || time.Now().After(entry.Expires) {
+		return nil, false
+	}
+	locations := make([]GeoLocation, len(entry.Locations))
+	copy(locations, entry.Locations)
+	return locations, true
+}
+
+// set stores the GeoLocation results for the given key. Expired entries are evicted and,
+// if configured, the cache is persisted in its JSON file
+func (g *GeocodeCache) set(key string, locations []GeoLocation) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	now := time.Now()
+	for entryKey, entry := range g.entries {
+		if now.After(entry.Expires) {
+			delete(g.entries, entryKey)
+		}
+	}
+	cached := make([]GeoLocation, len(locations))
+	copy(cached, locations)
+	g.entries[key] = geocodeCacheEntry{Expires: now.Add(g.ttl), Locations: cached}
+	g.persist()
+}
+
+// persist writes the entries of the GeocodeCache to its JSON file. The caller needs to
+// hold the mutex
+func (g *GeocodeCache) persist() {
+	if g.path == "" {
+		return
+	}
+	data, err := json.Marshal(g.entries)
+	if err != nil {
+		return
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(g.path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return
+	}
+	if err = os.Rename(tempFile.Name(), g.path); err != nil {
+		_ = os.Remove(tempFile.Name())
+	}
+}
+
+// geocodeCacheKey returns the GeocodeCache key for the given name, language and
+// GeoSearchOptions
+func geocodeCacheKey(name, language string, options GeoSearchOptions) string {
+	query := url.Values{}
+	options.apply(query)
+	return language + " " + normalizeGeocoderName(name) + " " + query.Encode()
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WithGeocodeCache(t *testing.T) {
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if r.URL.Query().Get("q") != "Cologne" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"place_id":1,"lat":"50.938361","lon":"6.959974","importance":0.8,` +
+			`"display_name":"Cologne, North Rhine-Westphalia, Germany"}]`))
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c := New(WithGeocoderURL(server.URL), WithGeocodeCache(NewGeocodeCache(0)), WithMetrics(metrics))
+	tt := []struct {
+		// Test name
+		n string
+		// Name to look up
+		q string
+		// Call options
+		o []CallOption
+		// Expected number of requests after the lookup
+		r int64
+		// Should fail
+		sf bool
+	}{
+		{"First lookup", "Cologne", nil, 1, false},
+		{"Cached lookup", "Cologne", nil, 1, false},
+		{"Different language", "Cologne", []CallOption{WithCallAcceptLanguage("de")}, 2, false},
+		{"Cached different language", "Cologne", []CallOption{WithCallAcceptLanguage("de")}, 2, false},
+		{
+			"Different search options", "Cologne",
+			[]CallOption{WithCallGeoSearch(GeoSearchOptions{Limit: 1})}, 3, false,
+		},
+		{"Cache bypass", "Cologne", []CallOption{WithCallCacheBypass()}, 4, false},
+		{"Not found", "Atlantis", nil, 5, true},
+		{"Not found is not cached", "Atlantis", nil, 6, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			l, err := c.GetGeoLocationByName(tc.q, tc.o...)
+			if tc.sf && !errors.Is(err, ErrCityNotFound) {
+				t.Errorf("GetGeoLocationByName was supposed to fail with ErrCityNotFound, got: %s", err)
+			}
+			if !tc.sf && err != nil {
+				t.Errorf("GetGeoLocationByName failed: %s", err)
+			}
+			if !tc.sf && l.Latitude != 50.938361 {
+				t.Errorf("GetGeoLocationByName failed, expected latitude: %f, got: %f", 50.938361, l.Latitude)
+			}
+			if r := atomic.LoadInt64(&requests); r != tc.r {
+				t.Errorf("GetGeoLocationByName failed, expected %d requests, got: %d", tc.r, r)
+			}
+		})
+	}
+	if hits := metrics.CacheHits(EndpointGeocode); hits != 2 {
+		t.Errorf("GeocodeCache metrics failed, expected 2 cache hits, got: %d", hits)
+	}
+}
+
+func TestNewGeocodeFileCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geocode", "cache.json")
+	cache, err := NewGeocodeFileCache(path, time.Hour)
+	if err != nil {
+		t.Errorf("NewGeocodeFileCache failed: %s", err)
+		return
+	}
+	key := geocodeCacheKey(" Cologne ", "en", GeoSearchOptions{})
+	cache.set(key, []GeoLocation{{
+		Name: "Cologne", Latitude: 50.938361, LatitudeString: "50.938361",
+		BoundingBox: BoundingBox{MinLatitude: 50.8, MaxLatitude: 51.1, MinLongitude: 6.7, MaxLongitude: 7.2},
+	}})
+
+	cache, err = NewGeocodeFileCache(path, time.Hour)
+	if err != nil {
+		t.Errorf("NewGeocodeFileCache failed: %s", err)
+		return
+	}
+	locations, ok := cache.get(geocodeCacheKey("cologne", "en", GeoSearchOptions{}))
+	if !ok || len(locations) != 1 {
+		t.Errorf("NewGeocodeFileCache failed, expected persisted entry to be loaded")
+		return
+	}
+	if locations[0].Latitude != 50.938361 || locations[0].BoundingBox.MaxLongitude != 7.2 {
+		t.Errorf("NewGeocodeFileCache failed, unexpected persisted location: %+v", locations[0])
+	}
+	if _, ok = cache.get(geocodeCacheKey("cologne", "de", GeoSearchOptions{})); ok {
+		t.Errorf("GeocodeCache get failed, expected no entry for a different language")
+	}
+
+	// Expired entries are not loaded
+	expiring, err := NewGeocodeFileCache(path, time.Nanosecond)
+	if err != nil {
+		t.Errorf("NewGeocodeFileCache failed: %s", err)
+		return
+	}
+	expiring.set(key, []GeoLocation{{Name: "Cologne"}})
+	time.Sleep(time.Millisecond)
+	if cache, err = NewGeocodeFileCache(path, time.Hour); err != nil || cache.Len() != 0 {
+		t.Errorf("NewGeocodeFileCache failed, expected expired entries not to be loaded")
+	}
+
+	if err = os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Errorf("failed to write test file: %s", err)
+		return
+	}
+	if _, err = NewGeocodeFileCache(path, time.Hour); err == nil {
+		t.Errorf("NewGeocodeFileCache was supposed to fail with an invalid cache file, but didn't")
+	}
+}
+
+func TestClient_WithGeocodeCache_EmptyResult(t *testing.T) {
+	cache := NewGeocodeCache(0)
+	c := New(WithGeocoder(emptyGeocoder{}), WithGeocodeCache(cache))
+	if _, err := c.GetGeoLocationsByName("Nowhere"); err != nil {
+		t.Errorf("GetGeoLocationsByName failed: %s", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("GeocodeCache failed, expected empty results not to be cached, got %d entries", cache.Len())
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Geocoder is the interface for looking up the GeoLocation of a place name. It is used by
+// GetGeoLocationsByName and all *ByLocation methods of the Client.
+//
+// Implementations need to be safe for concurrent use.
+type Geocoder interface {
+	// GeoLocationsByName returns the GeoLocations that match the given name, sorted by
+	// Importance with the highest importance as first entry. If no GeoLocation matches
+	// the name, ErrCityNotFound is returned
+	GeoLocationsByName(ctx context.Context, name string) ([]GeoLocation, error)
+}
+
+// ReverseGeocoder is the interface for looking up the GeoLocation of coordinates. If the
+// Geocoder of the Client implements it, it is used by ReverseGeoLocation and for
+// WithCallReverseGeocoding instead of the OSM Nominatim API.
+//
+// Implementations need to be safe for concurrent use.
+type ReverseGeocoder interface {
+	// ReverseGeoLocation returns the GeoLocation for the given coordinates. If no
+	// GeoLocation is found for the coordinates, ErrLocationNotFound is returned
+	ReverseGeoLocation(ctx context.Context, latitude, longitude float64) (GeoLocation, error)
+}
+
+// StaticGeocoder is an in-memory Geocoder that resolves names from a fixed list of
+// GeoLocations (a gazetteer), e.g. a list of your own sites. No API requests are
+// performed.
+//
+// A name matches a GeoLocation if it is equal to the GeoLocation's Name or to the first
+// comma-separated part of it. Names are compared case-insensitive and with normalized
+// whitespace, so "ehrenfeld" matches a GeoLocation with the Name "Ehrenfeld, Köln".
+type StaticGeocoder struct {
+	// locations maps the normalized names to the GeoLocations
+	locations map[string][]GeoLocation
+	// mutex protects the locations
+	mutex sync.RWMutex
+}
+
+// NewStaticGeocoder returns a new StaticGeocoder for the given GeoLocations
+func NewStaticGeocoder(locations ...GeoLocation) *StaticGeocoder {
+	geocoder := &StaticGeocoder{locations: make(map[string][]GeoLocation)}
+	geocoder.Add(locations...)
+	return geocoder
+}
+
+// Add adds the given GeoLocations to the StaticGeocoder. If the LatitudeString or
+// LongitudeString of a GeoLocation are empty, they are set based on the Latitude and
+// Longitude values
+func (s *StaticGeocoder) Add(locations ...GeoLocation) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, location := range locations {
+		if location.LatitudeString == "" {
+			location.LatitudeString = strconv.FormatFloat(location.Latitude, 'f', -1, 64)
+		}
+		if location.LongitudeString == "" {
+			location.LongitudeString = strconv.FormatFloat(location.Longitude, 'f', -1, 64)
+		}
+		for _, name := range staticGeocoderNames(location.Name) {
+			s.locations[name] = append(s.locations[name], location)
+			sort.SliceStable(s.locations[name], func(i, j int) bool {
+				return s.locations[name][i].Importance > s.locations[name][j].Importance
+			})
+		}
+	}
+}
+
+// GeoLocationsByName satisfies the Geocoder interface for the StaticGeocoder type
+func (s *StaticGeocoder) GeoLocationsByName(_ context.Context, name string) ([]GeoLocation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	locations, ok := s.locations[normalizeGeocoderName(name)]
+	if !ok || len(locations) < 1 {
+		return make([]GeoLocation, 0), ErrCityNotFound
+	}
+	result := make([]GeoLocation, len(locations))
+	copy(result, locations)
+	return result, nil
+}
+
+// staticGeocoderNames returns the normalized names under which a GeoLocation with the given
+// name can be found
+func staticGeocoderNames(name string) []string {
+	names := []string{normalizeGeocoderName(name)}
+	if first, _, ok := strings.Cut(name, ","); ok {
+		if short := normalizeGeocoderName(first); short != "" && short != names[0] {
+			names = append(names, short)
+		}
+	}
+	return names
+}
+
+// normalizeGeocoderName returns the given name in lower case and with normalized whitespace
+func normalizeGeocoderName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
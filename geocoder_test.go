@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStaticGeocoder_GeoLocationsByName(t *testing.T) {
+	geocoder := NewStaticGeocoder(
+		GeoLocation{Name: "Ehrenfeld, Köln", Latitude: 50.9497, Longitude: 6.9196, Importance: 0.3},
+		GeoLocation{Name: "Berlin", Latitude: 52.5170365, Longitude: 13.3888599, Importance: 0.9},
+	)
+	geocoder.Add(GeoLocation{Name: "Berlin, NH", Latitude: 44.4689, Longitude: -71.1851, Importance: 0.4})
+	tt := []struct {
+		// Test name
+		n string
+		// Name to look up
+		q string
+		// Expected number of results
+		c int
+		// Expected latitude of the first result
+		la float64
+		// Expected latitude string of the first result
+		ls string
+		// Should fail
+		sf bool
+	}{
+		{"Full name", "Ehrenfeld, Köln", 1, 50.9497, "50.9497", false},
+		{"First name part, case-insensitive", "  EHRENFELD ", 1, 50.9497, "50.9497", false},
+		{"Sorted by importance", "berlin", 2, 52.5170365, "52.5170365", false},
+		{"Unknown name", "Hamburg", 0, 0, "", true},
+		{"Empty name", "", 0, 0, "", true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			l, err := geocoder.GeoLocationsByName(context.Background(), tc.q)
+			if tc.sf {
+				if !errors.Is(err, ErrCityNotFound) {
+					t.Errorf("GeoLocationsByName was supposed to fail with ErrCityNotFound, got: %s", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GeoLocationsByName failed: %s", err)
+				return
+			}
+			if len(l) != tc.c {
+				t.Errorf("GeoLocationsByName failed, expected %d results, got: %d", tc.c, len(l))
+				return
+			}
+			if l[0].Latitude != tc.la {
+				t.Errorf("GeoLocationsByName failed, expected latitude: %f, got: %f", tc.la, l[0].Latitude)
+			}
+			if l[0].LatitudeString != tc.ls {
+				t.Errorf("GeoLocationsByName failed, expected latitude string: %s, got: %s", tc.ls,
+					l[0].LatitudeString)
+			}
+		})
+	}
+}
+
+func TestClient_WithGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/current/50.9497/6.9196" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"lat":50.9497,"lon":6.9196,"systemOfUnits":"metric","data":{}}`))
+	}))
+	defer server.Close()
+
+	geocoder := NewStaticGeocoder(GeoLocation{Name: "Office", Latitude: 50.9497, Longitude: 6.9196})
+	c := New(WithAPIBaseURL(server.URL), WithGeocoder(geocoder))
+	l, err := c.GetGeoLocationByName("office")
+	if err != nil {
+		t.Errorf("GetGeoLocationByName failed: %s", err)
+		return
+	}
+	if l.Name != "Office" {
+		t.Errorf("GetGeoLocationByName failed, expected name: %s, got: %s", "Office", l.Name)
+	}
+	cw, err := c.CurrentWeatherByLocation("Office")
+	if err != nil {
+		t.Errorf("CurrentWeatherByLocation failed: %s", err)
+		return
+	}
+	if cw.Latitude != 50.9497 {
+		t.Errorf("CurrentWeatherByLocation failed, expected latitude: %f, got: %f", 50.9497, cw.Latitude)
+	}
+	if _, err = c.GetGeoLocationByName("Home"); !errors.Is(err, ErrCityNotFound) {
+		t.Errorf("GetGeoLocationByName was supposed to fail with ErrCityNotFound, got: %s", err)
+	}
+
+	c = New(WithGeocoder(nil))
+	if _, ok := c.geocoder.(*NominatimGeocoder); !ok {
+		t.Errorf("WithGeocoder failed, expected nil Geocoder to keep the NominatimGeocoder default")
+	}
+}
+
+func TestNominatimGeocoder_GeoLocationsByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if r.URL.Query().Get("q") != "Cologne" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"place_id":2,"lat":"50.9","lon":"6.9","importance":0.2,"display_name":"Köln"},` +
+			`{"place_id":1,"lat":"50.938361","lon":"6.959974","importance":0.8,"display_name":"Cologne"}]`))
+	}))
+	defer server.Close()
+
+	var geocoder Geocoder = NewNominatimGeocoder(WithGeocoderURL(server.URL))
+	l, err := geocoder.GeoLocationsByName(context.Background(), "Cologne")
+	if err != nil {
+		t.Errorf("GeoLocationsByName failed: %s", err)
+		return
+	}
+	if len(l) != 2 || l[0].PlaceID != 1 {
+		t.Errorf("GeoLocationsByName failed, expected 2 results sorted by importance, got: %+v", l)
+	}
+	if _, err = geocoder.GeoLocationsByName(context.Background(), "Nowhere"); !errors.Is(err, ErrCityNotFound) {
+		t.Errorf("GeoLocationsByName was supposed to fail with ErrCityNotFound, got: %s", err)
+	}
+}
+
+// emptyGeocoder is a Geocoder that returns no results without an error
+type emptyGeocoder struct{}
+
+// GeoLocationsByName satisfies the Geocoder interface for the emptyGeocoder type
+func (emptyGeocoder) GeoLocationsByName(context.Context, string) ([]GeoLocation, error) {
+	return nil, nil
+}
+
+func TestClient_WithGeocoder_EmptyResult(t *testing.T) {
+	c := New(WithGeocoder(emptyGeocoder{}))
+	if _, err := c.GetGeoLocationByName("Nowhere"); !errors.Is(err, ErrCityNotFound) {
+		t.Errorf("GetGeoLocationByName was supposed to fail with ErrCityNotFound, got: %v", err)
+	}
+	if _, err := c.CurrentWeatherByLocation("Nowhere"); !errors.Is(err, ErrCityNotFound) {
+		t.Errorf("CurrentWeatherByLocation was supposed to fail with ErrCityNotFound, got: %v", err)
+	}
+}
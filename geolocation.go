@@ -5,22 +5,38 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"sort"
 	"strconv"
 )
 
-// OSMNominatimURL is the API endpoint URL for the OpenStreetMaps Nominatim API
-const OSMNominatimURL = "https://nominatim.openstreetmap.org/search"
+const (
+	// OSMNominatimBaseURL is the base URL for the OpenStreetMaps Nominatim API
+	OSMNominatimBaseURL = "https://nominatim.openstreetmap.org"
+	// OSMNominatimURL is the API endpoint URL for the OpenStreetMaps Nominatim API
+	OSMNominatimURL = OSMNominatimBaseURL + "/search"
+)
 
-// ErrCityNotFound is returned if a requested city was not found in the OSM API
-var ErrCityNotFound = errors.New("requested city not found in OSM Nominatim API")
+var (
+	// ErrCityNotFound is returned if a requested city was not found in the OSM API
+	ErrCityNotFound = errors.New("requested city not found in OSM Nominatim API")
+	// ErrLocationNotFound is returned if no location was found for the requested coordinates
+	// in the OSM API
+	ErrLocationNotFound = errors.New("no location found for the requested coordinates in OSM Nominatim API")
+)
 
 // GeoLocation represent the GPS GeoLocation coordinates of a City
 type GeoLocation struct {
+	// Address holds the address details of the GeoLocation
+	Address GeoAddress `json:"address"`
+	// BoundingBox is the area that covers the GeoLocation
+	BoundingBox BoundingBox `json:"boundingbox"`
+	// Class is the OSM main category of the GeoLocation (e.g. "place" or "boundary")
+	Class string `json:"class"`
 	// Importance is the OSM computed importance rank
 	Importance float64 `json:"importance"`
 	// Latitude represents the GPS Latitude coordinates of the requested City as Float
@@ -33,19 +49,64 @@ type GeoLocation struct {
 	LongitudeString string `json:"lon"`
 	// Name represents the requested City
 	Name string `json:"display_name"`
+	// OSMID is the ID of the OSM object of the GeoLocation
+	OSMID int64 `json:"osm_id"`
+	// OSMType is the type of the OSM object of the GeoLocation ("node", "way" or "relation")
+	OSMType string `json:"osm_type"`
 	// PlaceID is the OSM Nominatim internal database ID
 	PlaceID int64 `json:"place_id"`
+	// Type is the OSM subcategory of the GeoLocation (e.g. "city" or "administrative")
+	Type string `json:"type"`
+}
+
+// GeoAddress represents the address details of a GeoLocation as returned by the OSM
+// Nominatim API. Depending on the location, not all fields are set
+type GeoAddress struct {
+	// City is the name of the city of the GeoLocation
+	City string `json:"city"`
+	// Country is the name of the country of the GeoLocation
+	Country string `json:"country"`
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country of the GeoLocation
+	CountryCode string `json:"country_code"`
+	// County is the name of the county of the GeoLocation
+	County string `json:"county"`
+	// Municipality is the name of the municipality of the GeoLocation
+	Municipality string `json:"municipality"`
+	// Postcode is the postal code of the GeoLocation
+	Postcode string `json:"postcode"`
+	// Road is the name of the road of the GeoLocation
+	Road string `json:"road"`
+	// State is the name of the state (the top-level administrative area) of the GeoLocation
+	State string `json:"state"`
+	// Suburb is the name of the suburb of the GeoLocation
+	Suburb string `json:"suburb"`
+	// Town is the name of the town of the GeoLocation
+	Town string `json:"town"`
+	// Village is the name of the village of the GeoLocation
+	Village string `json:"village"`
 }
 
 // GetGeoLocationByName returns the GeoLocation with the highest importance based on
 // the given City name
 //
 // This method makes use of the OSM Nominatim API
-func (c *Client) GetGeoLocationByName(ci string) (GeoLocation, error) {
-	ga, err := c.GetGeoLocationsByName(ci)
-	if err != nil || len(ga) < 1 {
+func (c *Client) GetGeoLocationByName(ci string, options ...CallOption) (GeoLocation, error) {
+	return c.GetGeoLocationByNameContext(context.Background(), ci, options...)
+}
+
+// GetGeoLocationByNameContext returns the GeoLocation with the highest importance based on
+// the given City name using the provided context for the API request
+//
+// This method makes use of the OSM Nominatim API
+func (c *Client) GetGeoLocationByNameContext(ctx context.Context, ci string, options ...CallOption,
+) (GeoLocation, error) {
+	ga, err := c.GetGeoLocationsByNameContext(ctx, ci, options...)
+	if err != nil {
 		return GeoLocation{}, err
 	}
+	if len(ga) < 1 {
+		return GeoLocation{}, ErrCityNotFound
+	}
 	return ga[0], nil
 }
 
@@ -54,44 +115,238 @@ func (c *Client) GetGeoLocationByName(ci string) (GeoLocation, error) {
 // importance as first entry
 //
 // This method makes use of the OSM Nominatim API
-func (c *Client) GetGeoLocationsByName(city string) ([]GeoLocation, error) {
+func (c *Client) GetGeoLocationsByName(city string, options ...CallOption) ([]GeoLocation, error) {
+	return c.GetGeoLocationsByNameContext(context.Background(), city, options...)
+}
+
+// GetGeoLocationsByNameContext returns a slice of GeoLocation based on the requested City name
+// using the provided context for the API request
+//
+// This method makes use of the configured Geocoder, which defaults to the OSM Nominatim API
+func (c *Client) GetGeoLocationsByNameContext(ctx context.Context, city string, options ...CallOption,
+) ([]GeoLocation, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
+	cache := c.config.geocodeCache
+	key := geocodeCacheKey(city, call.language(c.config.acceptLang), call.geoSearch)
+	if cache != nil && !call.bypassCache {
+		locations, ok := cache.get(key)
+		c.config.metrics.observeCache(EndpointGeocode, ok)
+		if ok {
+			return locations, nil
+		}
+	}
+
+	var locations []GeoLocation
+	var err error
+	if nominatim, ok := c.geocoder.(*NominatimGeocoder); ok {
+		locations, err = nominatim.search(ctx, call, city, call.geoSearch)
+	} else {
+		locations, err = c.geocoder.GeoLocationsByName(ctx, city)
+	}
+	if err != nil {
+		return locations, err
+	}
+	if cache != nil && len(locations) > 0 {
+		cache.set(key, locations)
+	}
+	return locations, nil
+}
+
+// NominatimGeocoder is a Geocoder that makes use of the OSM Nominatim API. It is the default
+// Geocoder of the Client
+type NominatimGeocoder struct {
+	// httpClient is the HTTPClient used for requests to the OSM Nominatim API
+	httpClient *HTTPClient
+}
+
+// NewNominatimGeocoder returns a new NominatimGeocoder. The given Option values configure the
+// requests to the OSM Nominatim API (e.g. WithGeocoderURL, WithGeocoderRateLimit or
+// WithUserAgent)
+func NewNominatimGeocoder(options ...Option) *NominatimGeocoder {
+	return &NominatimGeocoder{httpClient: New(options...).httpClient}
+}
+
+// GeoLocationsByName satisfies the Geocoder interface for the NominatimGeocoder type
+func (n *NominatimGeocoder) GeoLocationsByName(ctx context.Context, name string) ([]GeoLocation, error) {
+	return n.search(ctx, nil, name, GeoSearchOptions{})
+}
+
+// search queries the OSM Nominatim API for the given name and GeoSearchOptions using the
+// per-call configuration (if any) of a Client method call
+func (n *NominatimGeocoder) search(ctx context.Context, call *callConfig, city string, options GeoSearchOptions,
+) ([]GeoLocation, error) {
 	locations := make([]GeoLocation, 0)
 
-	apiURL, err := url.Parse(OSMNominatimURL)
+	apiURL, err := url.Parse(n.httpClient.geocoderURL + "/search")
 	if err != nil {
 		return locations, fmt.Errorf("failed to parse OSM Nominatim URL: %w", err)
 	}
 	query := apiURL.Query()
 	query.Add("format", "json")
+	query.Add("addressdetails", "1")
 	query.Add("q", city)
+	options.apply(query)
 	apiURL.RawQuery = query.Encode()
 
-	response, err := c.httpClient.Get(apiURL.String())
+	response, err := n.httpClient.get(ctx, apiURL.String(), call)
 	if err != nil {
 		return locations, fmt.Errorf("OSM Nominatim API request failed: %w", err)
 	}
 	var jsonLocations []GeoLocation
-	if err = json.Unmarshal(response, &jsonLocations); err != nil {
-		return locations, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = n.httpClient.unmarshalResponse(ctx, apiURL.String(), response, &jsonLocations); err != nil {
+		return locations, err
 	}
 	if len(jsonLocations) < 1 {
 		return locations, ErrCityNotFound
 	}
 
 	for _, location := range jsonLocations {
-		latitude, err := strconv.ParseFloat(location.LatitudeString, 64)
-		if err != nil {
-			return locations, fmt.Errorf("failed to convert latitude string to float value: %w", err)
+		if err = location.parseCoordinates(); err != nil {
+			return locations, err
 		}
-		longitude, err := strconv.ParseFloat(location.LongitudeString, 64)
-		if err != nil {
-			return locations, fmt.Errorf("failed to convert longitude string to float value: %w", err)
-		}
-		location.Latitude = latitude
-		location.Longitude = longitude
 		locations = append(locations, location)
 	}
 	sort.SliceStable(locations, func(i, j int) bool { return locations[i].Importance > locations[j].Importance })
 
 	return locations, nil
 }
+
+// ReverseGeoLocation returns the GeoLocation for the given coordinates, including the display
+// name and the Address details of the location
+//
+// This method makes use of the OSM Nominatim API, unless the configured Geocoder implements
+// the ReverseGeocoder interface
+func (c *Client) ReverseGeoLocation(latitude, longitude float64, options ...CallOption) (GeoLocation, error) {
+	return c.ReverseGeoLocationContext(context.Background(), latitude, longitude, options...)
+}
+
+// ReverseGeoLocationContext returns the GeoLocation for the given coordinates using the
+// provided context for the API request
+//
+// This method makes use of the OSM Nominatim API, unless the configured Geocoder implements
+// the ReverseGeocoder interface. If the configured Geocoder is a NominatimGeocoder, its
+// configuration is used for the request
+func (c *Client) ReverseGeoLocationContext(ctx context.Context, latitude, longitude float64,
+	options ...CallOption,
+) (GeoLocation, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
+	return c.reverse(ctx, call, latitude, longitude)
+}
+
+// ReverseGeoLocation returns the GeoLocation for the given coordinates using the OSM
+// Nominatim reverse geocoding API. If no location is found for the coordinates (e.g. in
+// the open sea), ErrLocationNotFound is returned
+func (n *NominatimGeocoder) ReverseGeoLocation(ctx context.Context, latitude, longitude float64,
+) (GeoLocation, error) {
+	return n.reverse(ctx, nil, latitude, longitude)
+}
+
+// reverse queries the OSM Nominatim reverse geocoding API for the given coordinates using
+// the per-call configuration (if any) of a Client method call
+func (n *NominatimGeocoder) reverse(ctx context.Context, call *callConfig, latitude, longitude float64,
+) (GeoLocation, error) {
+	apiURL, err := url.Parse(n.httpClient.geocoderURL + "/reverse")
+	if err != nil {
+		return GeoLocation{}, fmt.Errorf("failed to parse OSM Nominatim URL: %w", err)
+	}
+	query := apiURL.Query()
+	query.Add("format", "json")
+	query.Add("lat", strconv.FormatFloat(latitude, 'f', -1, 64))
+	query.Add("lon", strconv.FormatFloat(longitude, 'f', -1, 64))
+	apiURL.RawQuery = query.Encode()
+
+	response, err := n.httpClient.get(ctx, apiURL.String(), call)
+	if err != nil {
+		return GeoLocation{}, fmt.Errorf("OSM Nominatim API request failed: %w", err)
+	}
+	var jsonLocation struct {
+		GeoLocation
+		// Error is set by the OSM Nominatim API if no location was found
+		Error string `json:"error"`
+	}
+	if err = n.httpClient.unmarshalResponse(ctx, apiURL.String(), response, &jsonLocation); err != nil {
+		return GeoLocation{}, err
+	}
+	if jsonLocation.Error != "" {
+		return GeoLocation{}, ErrLocationNotFound
+	}
+	location := jsonLocation.GeoLocation
+	if err = location.parseCoordinates(); err != nil {
+		return GeoLocation{}, err
+	}
+	return location, nil
+}
+
+// Locality returns the name of the locality (city, town, village or municipality) of the
+// GeoAddress. If none of them is set, an empty string is returned
+func (a GeoAddress) Locality() string {
+	for _, locality := range []string{a.City, a.Town, a.Village, a.Municipality} {
+		if locality != "" {
+			return locality
+		}
+	}
+	return ""
+}
+
+// parseCoordinates sets the Latitude and Longitude of the GeoLocation based on its
+// LatitudeString and LongitudeString values
+func (l *GeoLocation) parseCoordinates() error {
+	latitude, err := strconv.ParseFloat(l.LatitudeString, 64)
+	if err != nil {
+		return fmt.Errorf("failed to convert latitude string to float value: %w", err)
+	}
+	longitude, err := strconv.ParseFloat(l.LongitudeString, 64)
+	if err != nil {
+		return fmt.Errorf("failed to convert longitude string to float value: %w", err)
+	}
+	l.Latitude = latitude
+	l.Longitude = longitude
+	return nil
+}
+
+// nominatim returns the configured Geocoder if it is a NominatimGeocoder. Otherwise a
+// NominatimGeocoder based on the HTTPClient of the Client is returned
+func (c *Client) nominatim() *NominatimGeocoder {
+	if nominatim, ok := c.geocoder.(*NominatimGeocoder); ok {
+		return nominatim
+	}
+	return &NominatimGeocoder{httpClient: c.httpClient}
+}
+
+// reverse returns the GeoLocation for the given coordinates using the configured Geocoder
+// if it implements the ReverseGeocoder interface. Otherwise the OSM Nominatim API is used
+func (c *Client) reverse(ctx context.Context, call *callConfig, latitude, longitude float64,
+) (GeoLocation, error) {
+	if _, ok := c.geocoder.(*NominatimGeocoder); !ok {
+		if reverseGeocoder, ok := c.geocoder.(ReverseGeocoder); ok {
+			return reverseGeocoder.ReverseGeoLocation(ctx, latitude, longitude)
+		}
+	}
+	return c.nominatim().reverse(ctx, call, latitude, longitude)
+}
+
+// reverseGeoLocation returns the GeoLocation for the given coordinates if reverse geocoding
+// was requested for the method call via WithCallReverseGeocoding. The GeoLocation is only
+// an annotation of the results, so if the lookup fails, the failure is logged and nil is
+// returned
+func (c *Client) reverseGeoLocation(ctx context.Context, call *callConfig, latitude, longitude float64,
+) *GeoLocation {
+	if !call.reverseGeocode {
+		return nil
+	}
+	location, err := c.reverse(ctx, call, latitude, longitude)
+	if errors.Is(err, ErrLocationNotFound) {
+		return nil
+	}
+	if err != nil {
+		c.config.logger.LogAttrs(ctx, slog.LevelWarn, "failed to reverse geocode coordinates",
+			slog.Float64("latitude", latitude), slog.Float64("longitude", longitude),
+			slog.String("error", redactError(err)))
+		return nil
+	}
+	return &location
+}
@@ -5,7 +5,12 @@
 package meteologix
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -44,7 +49,7 @@ func TestClient_GetGeoLocationByCityName(t *testing.T) {
 		{"Nonexisting", "Nonexisting City", []string{}, 0, 0, true},
 	}
 
-	c := New()
+	c := New(withCassette(t, "geolocation"))
 	for _, tc := range tt {
 		t.Run(tc.n, func(t *testing.T) {
 			l, err := c.GetGeoLocationByName(tc.q)
@@ -75,7 +80,7 @@ func TestClient_GetGeoLocationByCityName(t *testing.T) {
 }
 
 func TestClient_GetGeoLocationByCityName_CityNotFoundErr(t *testing.T) {
-	c := New()
+	c := New(withCassette(t, "geolocation"))
 	_, err := c.GetGeoLocationByName("Nonexisting City")
 	if err == nil {
 		t.Errorf("GetGeoLocationByName with nonexisting city name was expected to fail, but didn't")
@@ -85,3 +90,157 @@ func TestClient_GetGeoLocationByCityName_CityNotFoundErr(t *testing.T) {
 		t.Errorf("GetGeoLocationByName was supposed to fail with ErrCityNotFound error, but didn't")
 	}
 }
+
+func TestClient_GetGeoLocationByName_WithGeocoderURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("q") != "Cologne, Germany" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`[{"place_id":1,"lat":"50.938361","lon":"6.959974","importance":0.8,` +
+			`"display_name":"Cologne, North Rhine-Westphalia, Germany"}]`))
+	}))
+	defer server.Close()
+
+	c := New(WithGeocoderURL(server.URL))
+	l, err := c.GetGeoLocationByName("Cologne, Germany")
+	if err != nil {
+		t.Errorf("GetGeoLocationByName failed: %s", err)
+		return
+	}
+	if l.Latitude != 50.938361 {
+		t.Errorf("GetGeoLocationByName failed, expected latitude: %f, got: %f", 50.938361, l.Latitude)
+	}
+	if l.Longitude != 6.959974 {
+		t.Errorf("GetGeoLocationByName failed, expected longitude: %f, got: %f", 6.959974, l.Longitude)
+	}
+}
+
+func TestClient_ReverseGeoLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if r.URL.Path != "/reverse" || r.URL.Query().Get("lat") != "50.9383" {
+			_, _ = w.Write([]byte(`{"error":"Unable to geocode"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"place_id":1,"lat":"50.938361","lon":"6.959974","importance":0.8,` +
+			`"display_name":"Cologne, North Rhine-Westphalia, Germany","address":{"city":"Cologne",` +
+			`"state":"North Rhine-Westphalia","country":"Germany"}}`))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		// Test name
+		n string
+		// Latitude
+		la float64
+		// Expected display name
+		dn string
+		// Expected locality
+		lo string
+		// Expected admin area
+		aa string
+		// Expected country
+		co string
+		// Should fail
+		sf bool
+	}{
+		{
+			"Cologne", 50.9383, "Cologne, North Rhine-Westphalia, Germany", "Cologne",
+			"North Rhine-Westphalia", "Germany", false,
+		},
+		{"Open sea", 54.5, "", "", "", "", true},
+	}
+	c := New(WithGeocoderURL(server.URL))
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			l, err := c.ReverseGeoLocation(tc.la, 6.9599)
+			if tc.sf {
+				if !errors.Is(err, ErrLocationNotFound) {
+					t.Errorf("ReverseGeoLocation was supposed to fail with ErrLocationNotFound, got: %s", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ReverseGeoLocation failed: %s", err)
+				return
+			}
+			if l.Name != tc.dn {
+				t.Errorf("ReverseGeoLocation failed, expected name: %s, got: %s", tc.dn, l.Name)
+			}
+			if l.Address.Locality() != tc.lo {
+				t.Errorf("ReverseGeoLocation failed, expected locality: %s, got: %s", tc.lo, l.Address.Locality())
+			}
+			if l.Address.State != tc.aa {
+				t.Errorf("ReverseGeoLocation failed, expected state: %s, got: %s", tc.aa, l.Address.State)
+			}
+			if l.Address.Country != tc.co {
+				t.Errorf("ReverseGeoLocation failed, expected country: %s, got: %s", tc.co, l.Address.Country)
+			}
+			if l.Latitude != 50.938361 {
+				t.Errorf("ReverseGeoLocation failed, expected latitude: %f, got: %f", 50.938361, l.Latitude)
+			}
+		})
+	}
+}
+
+// reverseStaticGeocoder is a StaticGeocoder that resolves coordinates north of the equator
+// to its first GeoLocation
+type reverseStaticGeocoder struct {
+	*StaticGeocoder
+}
+
+// ReverseGeoLocation satisfies the ReverseGeocoder interface for the reverseStaticGeocoder type
+func (r reverseStaticGeocoder) ReverseGeoLocation(ctx context.Context, latitude, _ float64,
+) (GeoLocation, error) {
+	locations, err := r.GeoLocationsByName(ctx, "Office")
+	if err != nil || latitude < 0 {
+		return GeoLocation{}, ErrLocationNotFound
+	}
+	return locations[0], nil
+}
+
+func TestClient_ReverseGeoLocation_ReverseGeocoder(t *testing.T) {
+	geocoder := reverseStaticGeocoder{NewStaticGeocoder(GeoLocation{Name: "Office", Latitude: 50.9497})}
+	c := New(WithGeocoder(geocoder), WithGeocoderURL("http://127.0.0.1:1"))
+	l, err := c.ReverseGeoLocation(50.9, 6.9)
+	if err != nil {
+		t.Errorf("ReverseGeoLocation failed: %s", err)
+		return
+	}
+	if l.Name != "Office" {
+		t.Errorf("ReverseGeoLocation failed, expected name: %s, got: %s", "Office", l.Name)
+	}
+	if _, err = c.ReverseGeoLocation(-45, 120); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("ReverseGeoLocation was supposed to fail with ErrLocationNotFound, got: %v", err)
+	}
+}
+
+func TestClient_CurrentWeatherByCoordinates_ReverseGeocodingFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/reverse" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"lat":50.9833,"lon":6.9833,"systemOfUnits":"metric","data":{}}`))
+	}))
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buffer, nil))
+	c := New(WithAPIBaseURL(server.URL), WithGeocoderURL(server.URL), WithLogger(logger))
+	cw, err := c.CurrentWeatherByCoordinates(50.9833, 6.9833, WithCallReverseGeocoding())
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates with failing reverse geocoding failed: %s", err)
+		return
+	}
+	if cw.Location != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected no Location, got: %+v", cw.Location)
+	}
+	if !strings.Contains(buffer.String(), "failed to reverse geocode coordinates") {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected reverse geocoding failure to be logged, "+
+			"got: %s", buffer.String())
+	}
+}
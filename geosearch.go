@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// List of feature types that search results of the OSM Nominatim API can be restricted to
+const (
+	// GeoFeatureTypeCity restricts the search results to cities
+	GeoFeatureTypeCity GeoFeatureType = "city"
+	// GeoFeatureTypeCountry restricts the search results to countries
+	GeoFeatureTypeCountry GeoFeatureType = "country"
+	// GeoFeatureTypeSettlement restricts the search results to any kind of settlement
+	// (states, cities, towns, villages, etc.)
+	GeoFeatureTypeSettlement GeoFeatureType = "settlement"
+	// GeoFeatureTypeState restricts the search results to states
+	GeoFeatureTypeState GeoFeatureType = "state"
+)
+
+// GeoFeatureType is a type wrapper for a string and represents the type of feature that
+// search results of the OSM Nominatim API can be restricted to
+type GeoFeatureType string
+
+// BoundingBox represents an area defined by its minimum and maximum coordinates
+type BoundingBox struct {
+	// MaxLatitude is the northern boundary of the BoundingBox
+	MaxLatitude float64
+	// MaxLongitude is the eastern boundary of the BoundingBox
+	MaxLongitude float64
+	// MinLatitude is the southern boundary of the BoundingBox
+	MinLatitude float64
+	// MinLongitude is the western boundary of the BoundingBox
+	MinLongitude float64
+}
+
+// GeoSearchOptions holds the query parameters for a search request to the OSM Nominatim
+// API. Zero values are not sent to the API
+type GeoSearchOptions struct {
+	// AcceptLanguage is the preferred language of the search results. If not set, the
+	// Accept-Language of the Client or method call is used
+	AcceptLanguage string
+	// Bounded restricts the search results to the ViewBox. If not set, the ViewBox is only
+	// used to prefer results within the area
+	Bounded bool
+	// CountryCodes restricts the search results to the given countries (ISO 3166-1 alpha-2
+	// codes, e.g. "de" or "us")
+	CountryCodes []string
+	// FeatureType restricts the search results to the given GeoFeatureType
+	FeatureType GeoFeatureType
+	// Limit is the maximum number of search results. The OSM Nominatim API defaults to 10
+	// and supports up to 40 results
+	Limit int
+	// ViewBox is the preferred area of the search results
+	ViewBox BoundingBox
+}
+
+// String satisfies the fmt.Stringer interface for the GeoFeatureType type
+func (t GeoFeatureType) String() string {
+	return string(t)
+}
+
+// IsZero returns true if no coordinates are set for the BoundingBox
+func (b BoundingBox) IsZero() bool {
+	return b == BoundingBox{}
+}
+
+// MarshalJSON encodes the BoundingBox in the format of the OSM Nominatim API:
+// ["min latitude", "max latitude", "min longitude", "max longitude"]
+func (b BoundingBox) MarshalJSON() ([]byte, error) {
+	if b.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]string{
+		strconv.FormatFloat(b.MinLatitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MaxLatitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MinLongitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MaxLongitude, 'f', -1, 64),
+	})
+}
+
+// UnmarshalJSON decodes the bounding box format of the OSM Nominatim API:
+// ["min latitude", "max latitude", "min longitude", "max longitude"]
+func (b *BoundingBox) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var values []json.Number
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to decode bounding box: %w", err)
+	}
+	if len(values) != 4 {
+		return fmt.Errorf("failed to decode bounding box, expected 4 values, got: %d", len(values))
+	}
+	coordinates := make([]float64, len(values))
+	for i, value := range values {
+		coordinate, err := value.Float64()
+		if err != nil {
+			return fmt.Errorf("failed to convert bounding box value to float value: %w", err)
+		}
+		coordinates[i] = coordinate
+	}
+	b.MinLatitude, b.MaxLatitude = coordinates[0], coordinates[1]
+	b.MinLongitude, b.MaxLongitude = coordinates[2], coordinates[3]
+	return nil
+}
+
+// viewBox returns the BoundingBox in the viewbox format of the OSM Nominatim API:
+// "min longitude,min latitude,max longitude,max latitude"
+func (b BoundingBox) viewBox() string {
+	return strings.Join([]string{
+		strconv.FormatFloat(b.MinLongitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MinLatitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MaxLongitude, 'f', -1, 64),
+		strconv.FormatFloat(b.MaxLatitude, 'f', -1, 64),
+	}, ",")
+}
+
+// Search returns the GeoLocations that match the given name, restricted by the given
+// GeoSearchOptions. The returned slice will be sorted by Importance of the results with
+// the highest importance as first entry
+func (n *NominatimGeocoder) Search(ctx context.Context, name string, options GeoSearchOptions,
+) ([]GeoLocation, error) {
+	return n.search(ctx, nil, name, options)
+}
+
+// apply adds the query parameters of the GeoSearchOptions to the given query
+func (o GeoSearchOptions) apply(query url.Values) {
+	if o.AcceptLanguage != "" {
+		query.Add("accept-language", o.AcceptLanguage)
+	}
+	if len(o.CountryCodes) > 0 {
+		countryCodes := make([]string, 0, len(o.CountryCodes))
+		for _, countryCode := range o.CountryCodes {
+			if countryCode = strings.ToLower(strings.TrimSpace(countryCode)); countryCode != "" {
+				countryCodes = append(countryCodes, countryCode)
+			}
+		}
+		if len(countryCodes) > 0 {
+			query.Add("countrycodes", strings.Join(countryCodes, ","))
+		}
+	}
+	if o.FeatureType != "" {
+		query.Add("featuretype", o.FeatureType.String())
+	}
+	if o.Limit > 0 {
+		query.Add("limit", strconv.Itoa(o.Limit))
+	}
+	if !o.ViewBox.IsZero() {
+		query.Add("viewbox", o.ViewBox.viewBox())
+		if o.Bounded {
+			query.Add("bounded", "1")
+		}
+	}
+}
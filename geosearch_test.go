@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGeoSearchOptions_apply(t *testing.T) {
+	viewBox := BoundingBox{MinLatitude: 50.8, MaxLatitude: 51.1, MinLongitude: 6.7, MaxLongitude: 7.2}
+	tt := []struct {
+		// Test name
+		n string
+		// GeoSearchOptions
+		o GeoSearchOptions
+		// Expected encoded query
+		q string
+	}{
+		{"Empty options", GeoSearchOptions{}, ""},
+		{
+			"Country codes", GeoSearchOptions{CountryCodes: []string{"US", " ca ", ""}},
+			"countrycodes=us%2Cca",
+		},
+		{"Limit", GeoSearchOptions{Limit: 5}, "limit=5"},
+		{"Negative limit", GeoSearchOptions{Limit: -1}, ""},
+		{"Feature type", GeoSearchOptions{FeatureType: GeoFeatureTypeCity}, "featuretype=city"},
+		{"Accept-Language", GeoSearchOptions{AcceptLanguage: "de"}, "accept-language=de"},
+		{"View box", GeoSearchOptions{ViewBox: viewBox}, "viewbox=6.7%2C50.8%2C7.2%2C51.1"},
+		{
+			"Bounded view box", GeoSearchOptions{ViewBox: viewBox, Bounded: true},
+			"bounded=1&viewbox=6.7%2C50.8%2C7.2%2C51.1",
+		},
+		{"Bounded without view box", GeoSearchOptions{Bounded: true}, ""},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			query := url.Values{}
+			tc.o.apply(query)
+			if query.Encode() != tc.q {
+				t.Errorf("GeoSearchOptions apply failed, expected query: %s, got: %s", tc.q, query.Encode())
+			}
+		})
+	}
+}
+
+func TestBoundingBox_JSON(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// JSON data
+		d string
+		// Expected BoundingBox
+		b BoundingBox
+		// Should fail
+		sf bool
+	}{
+		{
+			"Strings", `["50.8","51.1","6.7","7.2"]`,
+			BoundingBox{MinLatitude: 50.8, MaxLatitude: 51.1, MinLongitude: 6.7, MaxLongitude: 7.2}, false,
+		},
+		{
+			"Numbers", `[50.8,51.1,6.7,7.2]`,
+			BoundingBox{MinLatitude: 50.8, MaxLatitude: 51.1, MinLongitude: 6.7, MaxLongitude: 7.2}, false,
+		},
+		{"Null", `null`, BoundingBox{}, false},
+		{"Too few values", `["50.8","51.1"]`, BoundingBox{}, true},
+		{"Invalid value", `["north","51.1","6.7","7.2"]`, BoundingBox{}, true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			var boundingBox BoundingBox
+			if err := json.Unmarshal([]byte(tc.d), &boundingBox); err != nil {
+				if !tc.sf {
+					t.Errorf("BoundingBox UnmarshalJSON failed: %s", err)
+				}
+				return
+			}
+			if tc.sf {
+				t.Errorf("BoundingBox UnmarshalJSON was supposed to fail, but didn't")
+			}
+			if boundingBox != tc.b {
+				t.Errorf("BoundingBox UnmarshalJSON failed, expected: %+v, got: %+v", tc.b, boundingBox)
+			}
+			data, err := json.Marshal(boundingBox)
+			if err != nil {
+				t.Errorf("BoundingBox MarshalJSON failed: %s", err)
+				return
+			}
+			var decoded BoundingBox
+			if err = json.Unmarshal(data, &decoded); err != nil || decoded != tc.b {
+				t.Errorf("BoundingBox JSON round trip failed, expected: %+v, got: %+v", tc.b, decoded)
+			}
+		})
+	}
+}
+
+func TestClient_GetGeoLocationsByName_WithCallGeoSearch(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`[{"place_id":1,"osm_type":"relation","osm_id":123,"class":"boundary",` +
+			`"type":"administrative","lat":"39.7990175","lon":"-89.6439575","importance":0.7,` +
+			`"display_name":"Springfield, Sangamon County, Illinois, United States",` +
+			`"boundingbox":["39.6","39.9","-89.8","-89.5"],"address":{"city":"Springfield",` +
+			`"state":"Illinois","country":"United States","country_code":"us"}}]`))
+	}))
+	defer server.Close()
+
+	c := New(WithGeocoderURL(server.URL))
+	l, err := c.GetGeoLocationByName("Springfield", WithCallGeoSearch(GeoSearchOptions{
+		CountryCodes: []string{"us"},
+		FeatureType:  GeoFeatureTypeCity,
+		Limit:        1,
+	}))
+	if err != nil {
+		t.Errorf("GetGeoLocationByName failed: %s", err)
+		return
+	}
+	for key, value := range map[string]string{
+		"addressdetails": "1", "countrycodes": "us", "featuretype": "city", "limit": "1", "q": "Springfield",
+	} {
+		if query.Get(key) != value {
+			t.Errorf("GetGeoLocationByName failed, expected query parameter %s: %s, got: %s", key, value,
+				query.Get(key))
+		}
+	}
+	if l.OSMType != "relation" || l.OSMID != 123 {
+		t.Errorf("GetGeoLocationByName failed, expected OSM object: relation/123, got: %s/%d", l.OSMType, l.OSMID)
+	}
+	if l.Class != "boundary" || l.Type != "administrative" {
+		t.Errorf("GetGeoLocationByName failed, expected class/type: boundary/administrative, got: %s/%s",
+			l.Class, l.Type)
+	}
+	if l.Address.CountryCode != "us" || l.Address.State != "Illinois" {
+		t.Errorf("GetGeoLocationByName failed, expected country code/state: us/Illinois, got: %s/%s",
+			l.Address.CountryCode, l.Address.State)
+	}
+	if l.BoundingBox.MinLatitude != 39.6 || l.BoundingBox.MaxLongitude != -89.5 {
+		t.Errorf("GetGeoLocationByName failed, unexpected bounding box: %+v", l.BoundingBox)
+	}
+
+	geocoder := NewNominatimGeocoder(WithGeocoderURL(server.URL))
+	if _, err = geocoder.Search(context.Background(), "Springfield",
+		GeoSearchOptions{AcceptLanguage: "en"}); err != nil {
+		t.Errorf("NominatimGeocoder Search failed: %s", err)
+	}
+	if query.Get("accept-language") != "en" {
+		t.Errorf("NominatimGeocoder Search failed, expected accept-language: en, got: %s",
+			query.Get("accept-language"))
+	}
+}
@@ -4,4 +4,4 @@
 
 module github.com/wneessen/go-meteologix
 
-go 1.20
+go 1.21
@@ -10,6 +10,9 @@ import (
 	"time"
 )
 
+// MultiplierFeet is the multiplier for converting the base unit (meters) to feet
+const MultiplierFeet = 3.280839895
+
 // Height is a type wrapper of an WeatherData for holding height values in WeatherData
 // (based on meters a default unit)
 type Height WeatherData
@@ -85,3 +88,29 @@ func (h Height) MilliMeter() float64 {
 func (h Height) MilliMeterString() string {
 	return fmt.Sprintf("%.3fmm", h.MilliMeter())
 }
+
+// Feet returns the Height type value as float64 in feet.
+func (h Height) Feet() float64 {
+	if h.notAvailable {
+		return math.NaN()
+	}
+	return h.floatVal * MultiplierFeet
+}
+
+// FeetString returns the Height type as formatted string in feet
+func (h Height) FeetString() string {
+	return fmt.Sprintf("%.3fft", h.Feet())
+}
+
+// Inch returns the Height type value as float64 in inches.
+func (h Height) Inch() float64 {
+	if h.notAvailable {
+		return math.NaN()
+	}
+	return h.floatVal * MultiplierFeet * 12
+}
+
+// InchString returns the Height type as formatted string in inches
+func (h Height) InchString() string {
+	return fmt.Sprintf("%.3fin", h.Inch())
+}
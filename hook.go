@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"net/http"
+)
+
+// RequestHook is a function that is called by the HTTPClient before a HTTP request is
+// sent. It has access to the fully prepared http.Request (including authentication
+// headers) and can e.g. inject custom headers or record metrics.
+//
+// If a RequestHook returns an error, the request is aborted and the error is returned
+// to the caller.
+type RequestHook func(request *http.Request) error
+
+// ResponseHook is a function that is called by the HTTPClient after a HTTP response has
+// been received. It has access to the http.Response and the raw response body. Since
+// the response body has already been consumed, the body argument has to be used instead
+// of the http.Response's Body. The body must not be modified.
+//
+// ResponseHooks are called for successful and failed API responses alike. If a
+// ResponseHook returns an error, the error is returned to the caller.
+type ResponseHook func(response *http.Response, body []byte) error
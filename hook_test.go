@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_GetWithContext_Hooks(t *testing.T) {
+	payload := `{"code":200,"description":"OK"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Custom") != "custom" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	var order []string
+	var captured []byte
+	c := New(
+		WithRequestHook(func(r *http.Request) error {
+			order = append(order, "request1")
+			r.Header.Set("X-Custom", "custom")
+			return nil
+		}),
+		WithRequestHook(func(r *http.Request) error {
+			order = append(order, "request2")
+			return nil
+		}),
+		WithResponseHook(func(r *http.Response, body []byte) error {
+			order = append(order, "response")
+			if r.StatusCode != http.StatusOK {
+				t.Errorf("ResponseHook failed, expected status: %d, got: %d", http.StatusOK, r.StatusCode)
+			}
+			captured = append(captured, body...)
+			return nil
+		}),
+		WithRequestHook(nil),
+		WithResponseHook(nil),
+	)
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL); err != nil {
+		t.Errorf("HTTPClient GetWithContext with hooks failed: %s", err)
+		return
+	}
+	if len(order) != 3 || order[0] != "request1" || order[1] != "request2" || order[2] != "response" {
+		t.Errorf("HTTPClient GetWithContext with hooks failed, unexpected hook order: %v", order)
+	}
+	if string(captured) != payload {
+		t.Errorf("HTTPClient GetWithContext with hooks failed, expected body: %s, got: %s", payload, captured)
+	}
+}
+
+func TestHTTPClient_GetWithContext_HooksFail(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail":"not found"}`))
+	}))
+	defer server.Close()
+
+	hookErr := errors.New("hook failed")
+	c := New(WithRequestHook(func(*http.Request) error { return hookErr }))
+	_, err := c.httpClient.GetWithContext(context.Background(), server.URL)
+	if !errors.Is(err, hookErr) {
+		t.Errorf("HTTPClient GetWithContext was expected to fail with hook error, got: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("HTTPClient GetWithContext failed, expected request to be aborted by RequestHook")
+	}
+
+	var status int
+	c = New(WithResponseHook(func(r *http.Response, body []byte) error {
+		status = r.StatusCode
+		return nil
+	}))
+	_, err = c.httpClient.GetWithContext(context.Background(), server.URL)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("HTTPClient GetWithContext was expected to fail with ErrNotFound, got: %v", err)
+	}
+	if status != http.StatusNotFound {
+		t.Errorf("ResponseHook failed, expected to be called for failed responses with status: %d, got: %d",
+			http.StatusNotFound, status)
+	}
+
+	c = New(WithResponseHook(func(*http.Response, []byte) error { return hookErr }))
+	_, err = c.httpClient.GetWithContext(context.Background(), server.URL)
+	if !errors.Is(err, hookErr) {
+		t.Errorf("HTTPClient GetWithContext was expected to fail with hook error, got: %v", err)
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,16 +25,48 @@ const (
 	HTTPClientTimeout = time.Second * 10
 	// MIMETypeJSON is a string constant for application/json MIME type
 	MIMETypeJSON = "application/json"
+	// RedactedValue is the placeholder for credentials that have been redacted from log records
+	RedactedValue = "REDACTED"
 )
 
-// ErrNonJSONResponse is returned when a HTTPClient request did not return the expected
-// application/json content type
-var ErrNonJSONResponse = errors.New("HTTP response is of non-JSON content type")
+// redactedQueryParams is a list of URL query parameters that hold credentials and
+// will be redacted from log records
+var redactedQueryParams = []string{"access_token", "api_key", "apikey", "key", "password", "token"}
+
+var (
+	// ErrNonJSONResponse is returned when a HTTPClient request did not return the expected
+	// application/json content type
+	ErrNonJSONResponse = errors.New("HTTP response is of non-JSON content type")
+
+	// ErrUnauthorized is wrapped by an APIError when the API rejected the provided
+	// user authentication (HTTP 401)
+	ErrUnauthorized = errors.New("API request is unauthorized")
+	// ErrForbiddenBySubscription is wrapped by an APIError when the API subscription does
+	// not cover the requested resource, e.g. a station that is not part of the
+	// subscription (HTTP 403)
+	ErrForbiddenBySubscription = errors.New("API request is not covered by the subscription")
+	// ErrNotFound is wrapped by an APIError when the requested resource does not
+	// exist (HTTP 404)
+	ErrNotFound = errors.New("requested API resource not found")
+	// ErrRateLimited is wrapped by an APIError when the API rejected the request due to
+	// too many requests (HTTP 429)
+	ErrRateLimited = errors.New("API request has been rate limited")
+	// ErrUpstreamUnavailable is wrapped by an APIError when the API is not available due
+	// to a server side error (HTTP 5xx)
+	ErrUpstreamUnavailable = errors.New("API is currently unavailable")
+)
 
 // HTTPClient is a type wrapper for the Go stdlib http.Client and the Config
 type HTTPClient struct {
 	*Config
 	*http.Client
+
+	// flights coalesces concurrent identical requests
+	flights *flightGroup
+	// quota holds the latest Quota received from the Meteologix API
+	quota *quotaState
+	// validators holds the validators of responses for conditional requests
+	validators *validatorStore
 }
 
 // APIError wraps the error interface for the API
@@ -47,21 +79,52 @@ type APIError struct {
 }
 
 // NewHTTPClient returns a new HTTP client
+//
+// If the Config holds a caller-supplied http.Client or http.RoundTripper, those
+// will be used instead of the defaults. If the Config holds no slog.Logger,
+// slog.Default is used and if it holds no timeout, HTTPClientTimeout is used
 func NewHTTPClient(config *Config) *HTTPClient {
-	tlsConfig := &tls.Config{
-		MinVersion: tls.VersionTLS12,
+	if config.logger == nil {
+		config.logger = slog.Default()
 	}
-	httpTransport := &http.Transport{TLSClientConfig: tlsConfig}
-	httpClient := &http.Client{
-		Timeout:   HTTPClientTimeout,
+	if config.timeout <= 0 {
+		config.timeout = HTTPClientTimeout
+	}
+	hc := &HTTPClient{
+		Config:     config,
+		flights:    &flightGroup{},
+		quota:      newQuotaState(),
+		validators: newValidatorStore(config.conditionalCapacity),
+	}
+	if config.httpClient != nil {
+		if config.transport == nil {
+			hc.Client = config.httpClient
+			return hc
+		}
+		// Copy the provided http.Client, so that we don't alter the caller's instance
+		httpClient := *config.httpClient
+		httpClient.Transport = config.transport
+		hc.Client = &httpClient
+		return hc
+	}
+
+	httpTransport := config.transport
+	if httpTransport == nil {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+		httpTransport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	hc.Client = &http.Client{
+		Timeout:   config.timeout,
 		Transport: httpTransport,
 	}
-	return &HTTPClient{config, httpClient}
+	return hc
 }
 
 // Get performs a HTTP GET request for the given URL with the default HTTP timeout
 func (hc *HTTPClient) Get(url string) ([]byte, error) {
-	return hc.GetWithTimeout(url, HTTPClientTimeout)
+	return hc.GetWithTimeout(url, hc.timeout)
 }
 
 // GetWithTimeout performs a HTTP GET request for the given URL and sets a timeout context
@@ -69,40 +132,169 @@ func (hc *HTTPClient) Get(url string) ([]byte, error) {
 func (hc *HTTPClient) GetWithTimeout(url string, timeout time.Duration) ([]byte, error) {
 	ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
 	defer cancelFunc()
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return hc.GetWithContext(ctx, url)
+}
+
+// GetWithContext performs a HTTP GET request for the given URL using the provided context.
+// Deadlines and cancellation of the context are propagated to the HTTP request
+//
+// If a RetryPolicy is configured, failed requests will be retried according to it. If
+// a RateLimiter is configured for the requested upstream API, each attempt will wait
+// for the RateLimiter to permit it.
+//
+// Concurrent requests for the same URL are coalesced into a single upstream request.
+// Each caller receives its own copy of the response body
+func (hc *HTTPClient) GetWithContext(ctx context.Context, url string) ([]byte, error) {
+	body, err := hc.get(ctx, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	return bytes.Clone(body), nil
+}
+
+// get performs a HTTP GET request for the given URL using the provided context and the
+// per-call configuration (if any) of a Client method call. Concurrent identical requests
+// are coalesced
+func (hc *HTTPClient) get(ctx context.Context, url string, call *callConfig) ([]byte, error) {
+	if hc.flights == nil {
+		return hc.fetch(ctx, url, call)
+	}
+	return hc.flights.do(ctx, coalesceKey(call.language(hc.acceptLang), url), func() ([]byte, error) {
+		return hc.fetch(ctx, url, call)
+	})
+}
+
+// fetch performs a HTTP GET request for the given URL, including rate limiting, metrics
+// and retries
+func (hc *HTTPClient) fetch(ctx context.Context, url string, call *callConfig) ([]byte, error) {
+	attempts := 1
+	if hc.retryPolicy != nil {
+		attempts = hc.retryPolicy.attempts()
+	}
+	rateLimiter := hc.rateLimiter(url)
+	endpoint := hc.endpoint(url)
+	for attempt := 1; ; attempt++ {
+		if err := rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		body, response, err := hc.do(ctx, http.MethodGet, url, call)
+		hc.metrics.observeRequest(endpoint, time.Since(start), response, err)
+		if err == nil || attempt >= attempts || !hc.retryPolicy.retryable(ctx, http.MethodGet, response, err) {
+			return body, err
+		}
+		delay, ok := hc.retryPolicy.delay(ctx, attempt, response)
+		if !ok {
+			return nil, err
+		}
+		if ctxErr := sleepWithContext(ctx, delay); ctxErr != nil {
+			return nil, ctxErr
+		}
+	}
+}
+
+// do performs a single HTTP request with the given method for the given URL and returns
+// the response body. The returned http.Response (if any) has its body already closed and
+// is only provided for inspection of the status code and headers
+func (hc *HTTPClient) do(ctx context.Context, method, url string, call *callConfig) ([]byte, *http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
 	request.Header.Set("User-Agent", hc.userAgent)
 	request.Header.Set("Content-Type", MIMETypeJSON)
 	request.Header.Set("Accept", MIMETypeJSON)
-	request.Header.Set("Accept-Language", hc.acceptLang)
+	request.Header.Set("Accept-Language", call.language(hc.acceptLang))
+	validatorKey := coalesceKey(call.language(hc.acceptLang), url)
+	validated := hc.validators.apply(validatorKey, request)
 
 	// User authentication (only required for Meteologix API calls)
-	if strings.HasPrefix(url, APIBaseURL) {
-		hc.setAuthentication(request)
+	apiRequest := strings.HasPrefix(url, hc.apiURL+"/")
+	if apiRequest {
+		if err = hc.setAuthentication(ctx, request); err != nil {
+			return nil, nil, fmt.Errorf("failed to set user authentication: %w", err)
+		}
 	}
 
+	for _, hook := range hc.requestHooks {
+		if err = hook(request); err != nil {
+			return nil, nil, fmt.Errorf("request hook failed: %w", err)
+		}
+	}
+
+	start := time.Now()
 	response, err := hc.Do(request)
 	if err != nil {
-		return nil, err
+		hc.logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request failed",
+			slog.String("method", method), slog.String("url", redactURL(url)),
+			slog.Duration("duration", time.Since(start)), slog.String("error", redactError(err)))
+		return nil, nil, &transportError{err: err}
 	}
 	if response == nil {
-		return nil, errors.New("nil response received")
+		return nil, nil, &transportError{err: errors.New("nil response received")}
 	}
+	var bodyLength int64
 	defer func(body io.ReadCloser) {
-		if err = body.Close(); err != nil {
-			log.Printf("failed to close HTTP request body: %s", err)
+		if closeErr := body.Close(); closeErr != nil {
+			hc.logger.LogAttrs(ctx, slog.LevelWarn, "failed to close HTTP request body",
+				slog.String("url", redactURL(url)), slog.String("error", redactError(closeErr)))
 		}
+		hc.logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request completed",
+			slog.String("method", method), slog.String("url", redactURL(url)),
+			slog.Int("status", response.StatusCode), slog.Duration("duration", time.Since(start)),
+			slog.Int64("bytes", bodyLength))
 	}(response.Body)
 
+	buffer := &bytes.Buffer{}
+	bufferWriter := bufio.NewWriter(buffer)
+	bodyLength, err = io.Copy(bufferWriter, response.Body)
+	if err != nil {
+		return nil, response, fmt.Errorf("failed to copy HTTP response body to buffer: %w", err)
+	}
+	if err = bufferWriter.Flush(); err != nil {
+		return nil, response, fmt.Errorf("failed to flush buffer: %w", err)
+	}
+	body := buffer.Bytes()
+
+	if apiRequest {
+		if quota, ok := hc.quota.update(response); ok && hc.quotaCallback != nil {
+			hc.quotaCallback(quota)
+		}
+	}
+
+	for _, hook := range hc.responseHooks {
+		if err = hook(response, body); err != nil {
+			return nil, response, fmt.Errorf("response hook failed: %w", err)
+		}
+	}
+
+	// Rejected credentials should not be reused by an Authenticator. Only API requests
+	// carry the credentials, so a 401 of any other URL says nothing about them
+	if invalidator, ok := hc.authenticator.(interface{ Invalidate() }); ok && apiRequest &&
+		response.StatusCode == http.StatusUnauthorized {
+		invalidator.Invalidate()
+	}
+
+	// The resource has not been modified since we last requested it, so we can use
+	// the stored payload
+	if response.StatusCode == http.StatusNotModified && validated != nil {
+		return validated.body, response, nil
+	}
+
 	if !strings.HasPrefix(response.Header.Get("Content-Type"), MIMETypeJSON) {
-		return nil, ErrNonJSONResponse
+		if response.StatusCode >= http.StatusBadRequest {
+			apiError := APIError{Code: response.StatusCode, Details: response.Status}
+			return nil, response, fmt.Errorf("%w: %w", ErrNonJSONResponse, apiError)
+		}
+		return nil, response, ErrNonJSONResponse
 	}
 	if response.StatusCode >= http.StatusBadRequest {
 		apiError := new(APIError)
-		if err = json.NewDecoder(response.Body).Decode(apiError); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal error JSON: %w", err)
+		if err = json.Unmarshal(body, apiError); err != nil {
+			hc.logger.LogAttrs(ctx, slog.LevelWarn, "failed to decode API error response",
+				slog.String("url", redactURL(url)), slog.Int("status", response.StatusCode),
+				slog.String("error", redactError(err)))
+			return nil, response, fmt.Errorf("failed to unmarshal error JSON: %w", err)
 		}
 		if apiError.Code < 1 {
 			apiError.Code = response.StatusCode
@@ -110,35 +302,111 @@ func (hc *HTTPClient) GetWithTimeout(url string, timeout time.Duration) ([]byte,
 		if apiError.Details == "" {
 			apiError.Details = response.Status
 		}
-		return nil, *apiError
+		return nil, response, *apiError
 	}
+	hc.validators.store(validatorKey, response, body)
 
-	buffer := &bytes.Buffer{}
-	bufferWriter := bufio.NewWriter(buffer)
-	_, err = io.Copy(bufferWriter, response.Body)
+	return body, response, nil
+}
+
+// unmarshalResponse unmarshals the given API response JSON for the given URL into target.
+// Decoding problems are logged on warning level
+func (hc *HTTPClient) unmarshalResponse(ctx context.Context, url string, response []byte, target any) error {
+	if err := json.Unmarshal(response, target); err != nil {
+		hc.logger.LogAttrs(ctx, slog.LevelWarn, "failed to unmarshal API response JSON",
+			slog.String("url", redactURL(url)), slog.Int("bytes", len(response)),
+			slog.String("error", redactError(err)))
+		return fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	}
+	return nil
+}
+
+// rateLimiter returns the RateLimiter for the upstream API of the given URL. If no
+// RateLimiter is configured, nil is returned
+func (hc *HTTPClient) rateLimiter(url string) *RateLimiter {
+	switch {
+	case strings.HasPrefix(url, hc.apiURL+"/"):
+		return hc.apiRateLimiter
+	case strings.HasPrefix(url, hc.geocoderURL+"/"):
+		return hc.geocoderRateLimiter
+	default:
+		return nil
+	}
+}
+
+// redactURL returns the given URL with all user information and credential query
+// parameters redacted, so that it is safe to be logged
+func redactURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy HTTP response body to buffer: %w", err)
+		return RedactedValue
 	}
-	if err = bufferWriter.Flush(); err != nil {
-		return nil, fmt.Errorf("failed to flush buffer: %w", err)
+	if parsedURL.User != nil {
+		parsedURL.User = url.User(RedactedValue)
+	}
+	query := parsedURL.Query()
+	redacted := false
+	for key := range query {
+		for _, param := range redactedQueryParams {
+			if strings.EqualFold(key, param) {
+				query.Set(key, RedactedValue)
+				redacted = true
+			}
+		}
 	}
-	return buffer.Bytes(), nil
+	if redacted {
+		parsedURL.RawQuery = query.Encode()
+	}
+	return parsedURL.String()
 }
 
-// setAuthentication sets the corresponding user authentication header. If an API Key is set, this
-// will be preferred, alternatively a username/authPass combination for HTTP Basic auth can
-// be used
-func (hc *HTTPClient) setAuthentication(httpRequest *http.Request) {
-	if hc.apiKey != "" {
-		httpRequest.Header.Set("X-API-Key", hc.Config.apiKey)
-		return
+// redactError returns the message of the given error with the URL of a wrapped url.Error
+// redacted, so that it is safe to be logged
+func redactError(err error) string {
+	message := err.Error()
+	var urlError *url.Error
+	if errors.As(err, &urlError) && urlError.URL != "" {
+		message = strings.ReplaceAll(message, urlError.URL, redactURL(urlError.URL))
 	}
-	if hc.bearerToken != "" {
-		httpRequest.Header.Set("Authorization", "Bearer"+hc.bearerToken)
-		return
+	return message
+}
+
+// setAuthentication sets the corresponding user authentication header. If an Authenticator
+// is configured, it will supply the credentials. Otherwise, if an API Key is set, this will
+// be preferred, alternatively a bearer token or a username/authPass combination for HTTP
+// Basic auth can be used
+func (hc *HTTPClient) setAuthentication(ctx context.Context, httpRequest *http.Request) error {
+	if hc.authenticator != nil {
+		return hc.authenticator.Authenticate(ctx, httpRequest)
 	}
-	if hc.authUser != "" && hc.authPass != "" {
-		httpRequest.SetBasicAuth(url.QueryEscape(hc.authUser), url.QueryEscape(hc.authPass))
+	credentials{
+		apiKey:      hc.apiKey,
+		bearerToken: hc.bearerToken,
+		password:    hc.authPass,
+		username:    hc.authUser,
+	}.apply(httpRequest)
+	return nil
+}
+
+// Unwrap returns the sentinel error that corresponds to the HTTP status code of the
+// APIError. This allows to check for the different classes of API failures using
+// errors.Is, e.g. errors.Is(err, ErrForbiddenBySubscription).
+//
+// If no sentinel error corresponds to the status code, nil is returned
+func (e APIError) Unwrap() error {
+	switch {
+	case e.Code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.Code == http.StatusForbidden:
+		return ErrForbiddenBySubscription
+	case e.Code == http.StatusNotFound:
+		return ErrNotFound
+	case e.Code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.Code >= http.StatusInternalServerError:
+		return ErrUpstreamUnavailable
+	default:
+		return nil
 	}
 }
 
@@ -6,7 +6,11 @@ package meteologix
 
 import (
 	"fmt"
+	"log/slog"
+	"net/http"
 	"runtime"
+	"strings"
+	"time"
 )
 
 const (
@@ -29,6 +33,8 @@ var DefaultUserAgent = fmt.Sprintf("go-meteologix/v%s (%s; %s; "+
 type Client struct {
 	// config represents the Config for the Client
 	config *Config
+	// geocoder references the Geocoder used for GeoLocation lookups
+	geocoder Geocoder
 	// httpClient references the HTTPClient of the Server
 	httpClient *HTTPClient
 }
@@ -37,17 +43,57 @@ type Client struct {
 type Config struct {
 	// apiKey holds the (optional) API key for the API user authentication
 	apiKey string
+	// apiRateLimiter holds the (optional) RateLimiter for requests to the Meteologix API
+	apiRateLimiter *RateLimiter
 	// apiURL holds the base URL for the API. This is configurable so we
 	// can test against our mock API.
 	apiURL string
 	// acceptLang hold the (optional) accept-language tag
 	acceptLang string
+	// authenticator holds the (optional) Authenticator that supplies the user authentication
+	authenticator Authenticator
 	// authPass holds the (optional) passowrd for the API user authentication
 	authPass string
 	// authUser holds the (optional) username for the API user authentication
 	authUser string
+	// batchConcurrency holds the number of concurrent API requests of a batch method call
+	batchConcurrency int
 	// bearerToken holds the (optional) bearer token for the API authentication
 	bearerToken string
+	// cache holds the (optional) Cache for API responses
+	cache Cache
+	// conditionalCapacity holds the number of responses that are kept for conditional requests
+	conditionalCapacity int
+	// geocodeCache holds the (optional) GeocodeCache for geolocation lookups
+	geocodeCache *GeocodeCache
+	// geocoder holds the (optional) Geocoder that is used instead of the OSM Nominatim API
+	geocoder Geocoder
+	// geocoderRateLimiter holds the RateLimiter for requests to the OSM Nominatim API
+	geocoderRateLimiter *RateLimiter
+	// geocoderURL holds the base URL for the OSM Nominatim API used for GeoLocation lookups
+	geocoderURL string
+	// httpClient holds an (optional) caller-supplied http.Client that is used instead
+	// of the default http.Client
+	httpClient *http.Client
+	// logger holds the slog.Logger that is used for logging request details and warnings
+	logger *slog.Logger
+	// metrics holds the (optional) Metrics collector
+	metrics *Metrics
+	// quotaCallback holds the (optional) QuotaCallback that is called for each received Quota
+	quotaCallback QuotaCallback
+	// requestHooks holds the (optional) RequestHook functions that are called before each request
+	requestHooks []RequestHook
+	// responseHooks holds the (optional) ResponseHook functions that are called after each response
+	responseHooks []ResponseHook
+	// retryPolicy holds the (optional) RetryPolicy for failed HTTP requests
+	retryPolicy *RetryPolicy
+	// timeout holds the default timeout for HTTP requests
+	timeout time.Duration
+	// transport holds an (optional) caller-supplied http.RoundTripper that is used
+	// instead of the default http.Transport
+	transport http.RoundTripper
+	// unitSystem holds the UnitSystem that is requested from the API
+	unitSystem UnitSystem
 	// userAgent represents an alternative User-Agent HTTP header string
 	userAgent string
 }
@@ -59,8 +105,14 @@ type Option func(*Config)
 func New(options ...Option) *Client {
 	config := &Config{}
 	config.apiURL = APIBaseURL
+	config.geocoderURL = OSMNominatimBaseURL
 	config.acceptLang = DefaultAcceptLang
 	config.userAgent = DefaultUserAgent
+	config.logger = slog.Default()
+	config.timeout = HTTPClientTimeout
+	config.batchConcurrency = DefaultBatchConcurrency
+	config.conditionalCapacity = DefaultConditionalCapacity
+	config.unitSystem = UnitSystemMetric
 
 	// Set/override Config options
 	for _, option := range options {
@@ -70,10 +122,20 @@ func New(options ...Option) *Client {
 		option(config)
 	}
 
-	return &Client{
+	// The public OSM Nominatim API is always throttled according to its usage policy
+	if config.geocoderRateLimiter == nil && config.geocoderURL == OSMNominatimBaseURL {
+		config.geocoderRateLimiter = NewRateLimiter(DefaultGeocoderRateLimit, 1)
+	}
+
+	client := &Client{
 		config:     config,
+		geocoder:   config.geocoder,
 		httpClient: NewHTTPClient(config),
 	}
+	if client.geocoder == nil {
+		client.geocoder = &NominatimGeocoder{httpClient: client.httpClient}
+	}
+	return client
 }
 
 // WithAcceptLanguage sets the HTTP Accept-Lanauge header of the HTTP client
@@ -89,6 +151,21 @@ func WithAcceptLanguage(language string) Option {
 	}
 }
 
+// WithAPIBaseURL sets an alternative base URL for the Meteologix API (e.g. a caching
+// reverse proxy). The URL is expected to point to the API version root, equivalent
+// to APIBaseURL.
+//
+// User authentication is attached to all requests that are sent to the configured
+// base URL.
+func WithAPIBaseURL(baseURL string) Option {
+	if baseURL == "" {
+		return nil
+	}
+	return func(config *Config) {
+		config.apiURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
 // WithAPIKey sets the API Key for user authentication of the HTTP client
 func WithAPIKey(key string) Option {
 	if key == "" {
@@ -99,6 +176,41 @@ func WithAPIKey(key string) Option {
 	}
 }
 
+// WithAPIRateLimit throttles the requests sent to the Meteologix API to the given number
+// of requests per second, allowing bursts of up to burst requests. Requests that exceed
+// the rate limit will block until they are permitted or their context is done.
+func WithAPIRateLimit(requestsPerSecond float64, burst int) Option {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return func(config *Config) {
+		config.apiRateLimiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithAuthenticator sets an Authenticator that supplies the user authentication for each
+// API request. If set, it takes precedence over the static credentials set via WithAPIKey,
+// WithBearerToken and WithUsername/WithPassword.
+func WithAuthenticator(authenticator Authenticator) Option {
+	if authenticator == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.authenticator = authenticator
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of concurrent API requests of a batch
+// method call (e.g. CurrentWeatherBatch)
+func WithBatchConcurrency(concurrency int) Option {
+	if concurrency < 1 {
+		return nil
+	}
+	return func(config *Config) {
+		config.batchConcurrency = concurrency
+	}
+}
+
 // WithBearerToken uses a bearer token for the client authentication of the
 // HTTP client
 func WithBearerToken(token string) Option {
@@ -110,6 +222,126 @@ func WithBearerToken(token string) Option {
 	}
 }
 
+// WithCache sets a Cache that is used to store API responses. Responses are cached
+// with a TTL based on the endpoint type and the time the returned data was generated.
+//
+// See MemoryCache and FileCache for the provided implementations.
+func WithCache(cache Cache) Option {
+	if cache == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.cache = cache
+	}
+}
+
+// WithConditionalRequests sets the number of API responses for which the validators (ETag,
+// Last-Modified) and payloads are kept. Subsequent requests for these resources are sent as
+// conditional requests and the kept payload is returned if the API responds with HTTP 304
+// (Not Modified). A capacity of 0 disables conditional requests
+func WithConditionalRequests(capacity int) Option {
+	if capacity < 0 {
+		return nil
+	}
+	return func(config *Config) {
+		config.conditionalCapacity = capacity
+	}
+}
+
+// WithGeocodeCache sets a GeocodeCache that is used to store the results of GeoLocation
+// lookups, e.g. of GetGeoLocationsByName and all *ByLocation methods. Lookups that fail
+// are not cached.
+//
+// See NewGeocodeCache and NewGeocodeFileCache for in-memory and persistent caches.
+func WithGeocodeCache(cache *GeocodeCache) Option {
+	if cache == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.geocodeCache = cache
+	}
+}
+
+// WithGeocoder sets the Geocoder that is used for GeoLocation lookups, e.g. by
+// GetGeoLocationsByName and all *ByLocation methods. By default, the OSM Nominatim API
+// is used (see NominatimGeocoder)
+func WithGeocoder(geocoder Geocoder) Option {
+	if geocoder == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.geocoder = geocoder
+	}
+}
+
+// WithGeocoderRateLimit throttles the requests sent to the OSM Nominatim API to the given
+// number of requests per second, allowing bursts of up to burst requests. Requests that
+// exceed the rate limit will block until they are permitted or their context is done.
+//
+// If the public OSM Nominatim API is used, requests are limited to DefaultGeocoderRateLimit
+// by default.
+func WithGeocoderRateLimit(requestsPerSecond float64, burst int) Option {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return func(config *Config) {
+		config.geocoderRateLimiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithGeocoderURL sets an alternative base URL for the OSM Nominatim API (e.g. a
+// self-hosted Nominatim instance). The URL is expected to point to the Nominatim
+// root, equivalent to OSMNominatimBaseURL.
+func WithGeocoderURL(baseURL string) Option {
+	if baseURL == "" {
+		return nil
+	}
+	return func(config *Config) {
+		config.geocoderURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets a custom http.Client that is used by the HTTP client for all
+// API requests. This allows to inject e.g. proxies, custom CA pools or test doubles.
+//
+// The provided http.Client is used as is. If WithTransport is set as well, the
+// http.RoundTripper will be used in place of the Transport of the provided
+// http.Client.
+func WithHTTPClient(client *http.Client) Option {
+	if client == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.httpClient = client
+	}
+}
+
+// WithLogger sets a slog.Logger for the Client. Requests and responses are logged on
+// debug level, problems (e.g. decoding failures) are logged on warning level.
+//
+// Credentials like API keys, bearer tokens or basic auth user information are always
+// redacted from the log records. If no logger is set, slog.Default is used.
+func WithLogger(logger *slog.Logger) Option {
+	if logger == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.logger = logger
+	}
+}
+
+// WithMetrics sets a Metrics collector that records the API usage of the Client. The
+// Metrics can be exposed in the Prometheus text exposition format, since they satisfy
+// the http.Handler interface.
+func WithMetrics(metrics *Metrics) Option {
+	if metrics == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.metrics = metrics
+	}
+}
+
 // WithPassword sets the HTTP Basic auth authPass for the HTTP client
 func WithPassword(password string) Option {
 	if password == "" {
@@ -120,6 +352,87 @@ func WithPassword(password string) Option {
 	}
 }
 
+// WithQuotaCallback sets a QuotaCallback that is called whenever an API response with
+// rate-limit/quota headers has been received. This allows to back off before the
+// subscription limits are hit. The latest Quota is also available via Client.Quota
+func WithQuotaCallback(callback QuotaCallback) Option {
+	if callback == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.quotaCallback = callback
+	}
+}
+
+// WithRequestHook adds a RequestHook to the HTTP client. The RequestHook is called
+// before each HTTP request is sent. Multiple RequestHook functions are called in the
+// order they have been added.
+func WithRequestHook(hook RequestHook) Option {
+	if hook == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.requestHooks = append(config.requestHooks, hook)
+	}
+}
+
+// WithResponseHook adds a ResponseHook to the HTTP client. The ResponseHook is called
+// after each HTTP response has been received. Multiple ResponseHook functions are called
+// in the order they have been added.
+func WithResponseHook(hook ResponseHook) Option {
+	if hook == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.responseHooks = append(config.responseHooks, hook)
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed idempotent HTTP requests based on
+// the given RetryPolicy. Zero values in the RetryPolicy will be replaced with the
+// corresponding defaults.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(config *Config) {
+		config.retryPolicy = &policy
+	}
+}
+
+// WithTimeout sets the default timeout for HTTP requests of the HTTP client. If not set,
+// HTTPClientTimeout is used. The timeout is not applied to a http.Client provided via
+// WithHTTPClient.
+func WithTimeout(timeout time.Duration) Option {
+	if timeout <= 0 {
+		return nil
+	}
+	return func(config *Config) {
+		config.timeout = timeout
+	}
+}
+
+// WithTransport sets a custom http.RoundTripper that is used by the HTTP client
+// instead of the default http.Transport. This allows to inject e.g. proxies,
+// connection pooling limits or instrumenting round-trippers.
+func WithTransport(transport http.RoundTripper) Option {
+	if transport == nil {
+		return nil
+	}
+	return func(config *Config) {
+		config.transport = transport
+	}
+}
+
+// WithUnitSystem sets the UnitSystem that is requested from the API. The UnitSystem can
+// be overridden for a single method call with WithCallUnitSystem. Unsupported unit systems
+// are ignored
+func WithUnitSystem(unitSystem UnitSystem) Option {
+	if !unitSystem.supported() {
+		return nil
+	}
+	return func(config *Config) {
+		config.unitSystem = unitSystem
+	}
+}
+
 // WithUserAgent sets a custom user agent string for the HTTP client
 func WithUserAgent(userAgent string) Option {
 	if userAgent == "" {
@@ -5,8 +5,28 @@
 package meteologix
 
 import (
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/wneessen/go-meteologix/replay"
+)
+
+// envRecordCassettes is the environment variable that switches withCassette from replaying
+// to recording all cassettes ("record") or only the missing ones ("auto")
+const envRecordCassettes = "METEOLOGIX_RECORD"
+
+var (
+	// recorders holds the recording replay.Transport of each cassette file, so that all
+	// tests using the same cassette are recorded into the same file
+	recorders = make(map[string]*replay.Transport)
+	// recordersMutex protects the recorders
+	recordersMutex sync.Mutex
 )
 
 func TestNew(t *testing.T) {
@@ -45,6 +65,26 @@ func TestNew_WithAcceptLanguage(t *testing.T) {
 	}
 }
 
+func TestNew_WithAPIBaseURL(t *testing.T) {
+	e := "https://proxy.example.com/v02"
+	c := New(WithAPIBaseURL(e + "/"))
+	if c == nil {
+		t.Errorf("NewWithAPIBaseURL failed, expected Client, got nil")
+		return
+	}
+	if c.config.apiURL != e {
+		t.Errorf("NewWithAPIBaseURL failed, expected URL value: %s, got: %s", e, c.config.apiURL)
+	}
+	c = New(WithAPIBaseURL(""))
+	if c == nil {
+		t.Errorf("NewWithAPIBaseURL failed, expected Client, got nil")
+		return
+	}
+	if c.config.apiURL != APIBaseURL {
+		t.Errorf("NewWithAPIBaseURL failed, expected URL value: %s, got: %s", APIBaseURL, c.config.apiURL)
+	}
+}
+
 func TestNew_WithAPIKey(t *testing.T) {
 	e := "API-KEY"
 	c := New(WithAPIKey(e))
@@ -109,6 +149,26 @@ func TestNew_WithUsername(t *testing.T) {
 	}
 }
 
+func TestNew_WithLogger(t *testing.T) {
+	e := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := New(WithLogger(e))
+	if c == nil {
+		t.Errorf("NewWithLogger failed, expected Client, got nil")
+		return
+	}
+	if c.config.logger != e {
+		t.Errorf("NewWithLogger failed, expected provided logger to be used")
+	}
+	c = New(WithLogger(nil))
+	if c == nil {
+		t.Errorf("NewWithLogger failed, expected Client, got nil")
+		return
+	}
+	if c.config.logger != slog.Default() {
+		t.Errorf("NewWithLogger failed, expected default logger to be used")
+	}
+}
+
 func TestNew_WithPassword(t *testing.T) {
 	e := "password"
 	c := New(WithPassword(e))
@@ -152,6 +212,131 @@ func TestNew_WithUserAgent(t *testing.T) {
 	}
 }
 
+func TestNew_WithGeocoderURL(t *testing.T) {
+	e := "https://nominatim.example.com"
+	c := New(WithGeocoderURL(e + "/"))
+	if c == nil {
+		t.Errorf("NewWithGeocoderURL failed, expected Client, got nil")
+		return
+	}
+	if c.config.geocoderURL != e {
+		t.Errorf("NewWithGeocoderURL failed, expected URL value: %s, got: %s", e, c.config.geocoderURL)
+	}
+	c = New(WithGeocoderURL(""))
+	if c == nil {
+		t.Errorf("NewWithGeocoderURL failed, expected Client, got nil")
+		return
+	}
+	if c.config.geocoderURL != OSMNominatimBaseURL {
+		t.Errorf("NewWithGeocoderURL failed, expected URL value: %s, got: %s", OSMNominatimBaseURL,
+			c.config.geocoderURL)
+	}
+}
+
+func TestNew_WithHTTPClient(t *testing.T) {
+	e := &http.Client{Timeout: time.Second}
+	c := New(WithHTTPClient(e))
+	if c == nil {
+		t.Errorf("NewWithHTTPClient failed, expected Client, got nil")
+		return
+	}
+	if c.httpClient.Client != e {
+		t.Errorf("NewWithHTTPClient failed, expected provided http.Client to be used")
+	}
+	c = New(WithHTTPClient(nil))
+	if c == nil {
+		t.Errorf("NewWithHTTPClient failed, expected Client, got nil")
+		return
+	}
+	if c.config.httpClient != nil {
+		t.Errorf("NewWithHTTPClient failed, expected nil http.Client, got: %v", c.config.httpClient)
+	}
+	if c.httpClient.Timeout != HTTPClientTimeout {
+		t.Errorf("NewWithHTTPClient failed, expected timeout: %s, got: %s", HTTPClientTimeout,
+			c.httpClient.Timeout)
+	}
+}
+
+func TestNew_WithTimeout(t *testing.T) {
+	e := time.Second * 3
+	c := New(WithTimeout(e))
+	if c == nil {
+		t.Errorf("NewWithTimeout failed, expected Client, got nil")
+		return
+	}
+	if c.httpClient.Timeout != e {
+		t.Errorf("NewWithTimeout failed, expected timeout: %s, got: %s", e, c.httpClient.Timeout)
+	}
+	c = New(WithTimeout(0))
+	if c == nil {
+		t.Errorf("NewWithTimeout failed, expected Client, got nil")
+		return
+	}
+	if c.httpClient.Timeout != HTTPClientTimeout {
+		t.Errorf("NewWithTimeout failed, expected timeout: %s, got: %s", HTTPClientTimeout,
+			c.httpClient.Timeout)
+	}
+}
+
+func TestNew_WithUnitSystem(t *testing.T) {
+	c := New(WithUnitSystem(UnitSystemImperial))
+	if c == nil {
+		t.Errorf("NewWithUnitSystem failed, expected Client, got nil")
+		return
+	}
+	if c.config.unitSystem != UnitSystemImperial {
+		t.Errorf("NewWithUnitSystem failed, expected unit system: %s, got: %s", UnitSystemImperial,
+			c.config.unitSystem)
+	}
+	for _, unitSystem := range []UnitSystem{"", "kelvin"} {
+		c = New(WithUnitSystem(unitSystem))
+		if c == nil {
+			t.Errorf("NewWithUnitSystem failed, expected Client, got nil")
+			return
+		}
+		if c.config.unitSystem != UnitSystemMetric {
+			t.Errorf("NewWithUnitSystem failed, expected unit system: %s, got: %s", UnitSystemMetric,
+				c.config.unitSystem)
+		}
+	}
+}
+
+func TestNew_WithTransport(t *testing.T) {
+	e := &http.Transport{}
+	c := New(WithTransport(e))
+	if c == nil {
+		t.Errorf("NewWithTransport failed, expected Client, got nil")
+		return
+	}
+	if c.httpClient.Transport != e {
+		t.Errorf("NewWithTransport failed, expected provided http.RoundTripper to be used")
+	}
+	hc := &http.Client{Timeout: time.Second}
+	c = New(WithHTTPClient(hc), WithTransport(e))
+	if c == nil {
+		t.Errorf("NewWithTransport failed, expected Client, got nil")
+		return
+	}
+	if c.httpClient.Transport != e {
+		t.Errorf("NewWithTransport failed, expected provided http.RoundTripper to be used")
+	}
+	if hc.Transport != nil {
+		t.Errorf("NewWithTransport failed, provided http.Client was not supposed to be altered")
+	}
+	if c.httpClient.Timeout != time.Second {
+		t.Errorf("NewWithTransport failed, expected timeout: %s, got: %s", time.Second,
+			c.httpClient.Timeout)
+	}
+	c = New(WithTransport(nil))
+	if c == nil {
+		t.Errorf("NewWithTransport failed, expected Client, got nil")
+		return
+	}
+	if c.config.transport != nil {
+		t.Errorf("NewWithTransport failed, expected nil transport, got: %v", c.config.transport)
+	}
+}
+
 func TestNew_withMockAPI(t *testing.T) {
 	c := New(withMockAPI())
 	if c == nil {
@@ -184,3 +369,63 @@ func getAPIKeyFromEnv(t *testing.T) string {
 	t.Helper()
 	return os.Getenv("API_KEY")
 }
+
+// withCassette returns an Option that replays the recorded API interactions of the given
+// cassette file in the testdata directory, so that tests against the mock API and the OSM
+// Nominatim API do not require network access.
+//
+// If envRecordCassettes is set to "record" (or to "auto" and the cassette file does not
+// exist), the interactions are recorded from the upstream APIs instead and the cassette
+// file is written once the test has finished
+func withCassette(t *testing.T, cassette string) Option {
+	t.Helper()
+	transport, err := cassetteTransport(filepath.Join("testdata", cassette+".json"))
+	if err != nil {
+		t.Errorf("failed to load cassette %s: %s", cassette, err)
+		return nil
+	}
+	if transport.IsRecording() {
+		t.Cleanup(func() {
+			if err := transport.Save(); err != nil {
+				t.Errorf("failed to save cassette %s: %s", cassette, err)
+			}
+		})
+		return func(config *Config) {
+			config.transport = transport
+		}
+	}
+	return func(config *Config) {
+		config.transport = transport
+		// Replayed interactions do not need to respect the Nominatim usage policy
+		config.geocoderRateLimiter = NewRateLimiter(1000, 1000)
+	}
+}
+
+// cassetteTransport returns the replay.Transport for the cassette file at the given path
+// in the replay.Mode selected via envRecordCassettes
+func cassetteTransport(path string) (*replay.Transport, error) {
+	mode := replay.ModeReplay
+	switch os.Getenv(envRecordCassettes) {
+	case "record":
+		mode = replay.ModeRecord
+	case "auto":
+		mode = replay.ModeAuto
+	}
+	if mode == replay.ModeReplay {
+		return replay.NewTransport(path, mode, nil)
+	}
+
+	recordersMutex.Lock()
+	defer recordersMutex.Unlock()
+	if transport, ok := recorders[path]; ok {
+		return transport, nil
+	}
+	transport, err := replay.NewTransport(path, mode, nil)
+	if err != nil {
+		return nil, err
+	}
+	if transport.IsRecording() {
+		recorders[path] = transport
+	}
+	return transport, nil
+}
@@ -0,0 +1,612 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+// Package meteologixtest provides an in-process fake of the Kachelmann-Wetter API and
+// the OSM Nominatim API for unit-testing code that is built on the meteologix Client
+// without network access.
+//
+// The fake Server returns plausible default responses for all supported endpoints. The
+// responses can be overridden per endpoint, errors can be injected and all received
+// requests are recorded for assertions.
+package meteologixtest
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wneessen/go-meteologix"
+)
+
+const (
+	// APIPath is the path prefix of the fake Kachelmann-Wetter API
+	APIPath = "/v02"
+	// GeocoderPath is the path prefix of the fake OSM Nominatim API
+	GeocoderPath = "/nominatim"
+	// DefaultStationID is the station ID returned by the default station search response
+	DefaultStationID = "H744"
+	// ReverseGeocodeRadius is the maximum distance in degrees between the requested
+	// coordinates and a location for reverse geocoding requests
+	ReverseGeocodeRadius = 0.5
+)
+
+// Server is a fake Kachelmann-Wetter and OSM Nominatim API server. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// apiKey holds the API key that is required for API requests (if set)
+	apiKey string
+	// injected holds the queued error responses per Endpoint
+	injected map[meteologix.Endpoint][]Response
+	// locations holds the locations known to the fake OSM Nominatim API
+	locations []Location
+	// mutex protects the Server state
+	mutex sync.Mutex
+	// overrides holds the response overrides per Endpoint
+	overrides map[meteologix.Endpoint]Response
+	// requests holds the recorded requests
+	requests []Request
+}
+
+// Response is a programmable response of the fake Server
+type Response struct {
+	// Body is the response body. String and []byte values are sent as is, any other
+	// value is encoded as JSON
+	Body any
+	// ContentType is the Content-Type of the response. Defaults to application/json
+	ContentType string
+	// Header holds additional HTTP headers of the response
+	Header http.Header
+	// StatusCode is the HTTP status code of the response. Defaults to 200
+	StatusCode int
+}
+
+// Request is a request that has been received by the fake Server
+type Request struct {
+	// Endpoint is the endpoint type that was requested
+	Endpoint meteologix.Endpoint
+	// Header holds the HTTP request headers
+	Header http.Header
+	// Method is the HTTP method of the request
+	Method string
+	// Path is the URL path of the request
+	Path string
+	// Query holds the URL query parameters of the request
+	Query url.Values
+}
+
+// Location is a location known to the fake OSM Nominatim API
+type Location struct {
+	// Country is the name of the country of the location
+	Country string
+	// CountryCode is the ISO 3166-1 alpha-2 code of the country of the location
+	CountryCode string
+	// Importance is the OSM importance rank of the location
+	Importance float64
+	// Latitude is the latitude of the location
+	Latitude float64
+	// Longitude is the longitude of the location
+	Longitude float64
+	// Name is the display name of the location
+	Name string
+	// State is the name of the state of the location
+	State string
+}
+
+// NewServer starts and returns a new fake Server. It needs to be closed by the caller
+func NewServer() *Server {
+	server := &Server{
+		injected:  make(map[meteologix.Endpoint][]Response),
+		overrides: make(map[meteologix.Endpoint]Response),
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+// NewTestServer starts and returns a new fake Server that is closed automatically when
+// the given test finishes
+func NewTestServer(tb testing.TB) *Server {
+	tb.Helper()
+	server := NewServer()
+	tb.Cleanup(server.Close)
+	return server
+}
+
+// ErrorResponse returns a JSON API error Response with the given HTTP status code, as
+// returned by the Kachelmann-Wetter API
+func ErrorResponse(statusCode int) Response {
+	return Response{
+		Body: map[string]any{
+			"status": statusCode,
+			"title":  http.StatusText(statusCode),
+			"detail": http.StatusText(statusCode),
+		},
+		StatusCode: statusCode,
+	}
+}
+
+// NonJSONResponse returns a HTML Response with the given HTTP status code, as returned
+// e.g. by a misbehaving reverse proxy
+func NonJSONResponse(statusCode int) Response {
+	return Response{
+		Body:        "<html><body><h1>" + http.StatusText(statusCode) + "</h1></body></html>",
+		ContentType: "text/html; charset=utf-8",
+		StatusCode:  statusCode,
+	}
+}
+
+// APIURL returns the base URL of the fake Kachelmann-Wetter API
+func (s *Server) APIURL() string {
+	return s.URL + APIPath
+}
+
+// GeocoderURL returns the base URL of the fake OSM Nominatim API
+func (s *Server) GeocoderURL() string {
+	return s.URL + GeocoderPath
+}
+
+// ClientOptions returns the meteologix.Option values that configure a meteologix Client
+// to use the fake Server
+func (s *Server) ClientOptions() []meteologix.Option {
+	return []meteologix.Option{
+		meteologix.WithAPIBaseURL(s.APIURL()),
+		meteologix.WithGeocoderURL(s.GeocoderURL()),
+	}
+}
+
+// RequireAPIKey makes the fake Server reject all API requests that do not present the
+// given API key with HTTP 401
+func (s *Server) RequireAPIKey(apiKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.apiKey = apiKey
+}
+
+// AddLocation adds a location to the fake OSM Nominatim API. Search queries match all
+// locations whose name contains the query (case-insensitive). Reverse geocoding requests
+// return the nearest location within ReverseGeocodeRadius degrees
+func (s *Server) AddLocation(location Location) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.locations = append(s.locations, location)
+}
+
+// SetResponse overrides the default response for the given endpoint
+func (s *Server) SetResponse(endpoint meteologix.Endpoint, response Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.overrides[endpoint] = response
+}
+
+// InjectError makes the next count requests to the given endpoint fail with the given
+// Response (see ErrorResponse and NonJSONResponse)
+func (s *Server) InjectError(endpoint meteologix.Endpoint, count int, response Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := 0; i < count; i++ {
+		s.injected[endpoint] = append(s.injected[endpoint], response)
+	}
+}
+
+// Reset removes all response overrides, injected errors and recorded requests
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.injected = make(map[meteologix.Endpoint][]Response)
+	s.overrides = make(map[meteologix.Endpoint]Response)
+	s.requests = nil
+}
+
+// Requests returns all requests received by the fake Server
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// RequestsTo returns all requests received by the fake Server for the given endpoint
+func (s *Server) RequestsTo(endpoint meteologix.Endpoint) []Request {
+	var requests []Request
+	for _, request := range s.Requests() {
+		if request.Endpoint == endpoint {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// AssertRequestCount fails the given test if the number of requests received for the
+// given endpoint does not match the expected count
+func (s *Server) AssertRequestCount(tb testing.TB, endpoint meteologix.Endpoint, expected int) {
+	tb.Helper()
+	if count := len(s.RequestsTo(endpoint)); count != expected {
+		tb.Errorf("meteologixtest: expected %d requests to endpoint %q, got: %d", expected, endpoint, count)
+	}
+}
+
+// AssertHeader fails the given test if the last request to the given endpoint did not
+// carry the expected value for the given HTTP header
+func (s *Server) AssertHeader(tb testing.TB, endpoint meteologix.Endpoint, header, expected string) {
+	tb.Helper()
+	requests := s.RequestsTo(endpoint)
+	if len(requests) < 1 {
+		tb.Errorf("meteologixtest: expected request to endpoint %q, got none", endpoint)
+		return
+	}
+	if value := requests[len(requests)-1].Header.Get(header); value != expected {
+		tb.Errorf("meteologixtest: expected header %q for endpoint %q to be %q, got: %q", header,
+			endpoint, expected, value)
+	}
+}
+
+// handle is the http.HandlerFunc of the fake Server
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	endpoint, parameters := route(r.URL.Path)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Header:   r.Header.Clone(),
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Query:    r.URL.Query(),
+	})
+	apiKey := s.apiKey
+	var response *Response
+	if injected := s.injected[endpoint]; len(injected) > 0 {
+		response = &injected[0]
+		s.injected[endpoint] = injected[1:]
+	} else if override, ok := s.overrides[endpoint]; ok {
+		response = &override
+	}
+	s.mutex.Unlock()
+
+	switch {
+	case endpoint == meteologix.EndpointUnknown:
+		writeResponse(w, ErrorResponse(http.StatusNotFound))
+		return
+	case r.Method != http.MethodGet:
+		writeResponse(w, ErrorResponse(http.StatusMethodNotAllowed))
+		return
+	case endpoint != meteologix.EndpointGeocode && endpoint != meteologix.EndpointReverseGeocode &&
+		apiKey != "" && r.Header.Get("X-API-Key") != apiKey:
+		writeResponse(w, ErrorResponse(http.StatusUnauthorized))
+		return
+	case response != nil:
+		writeResponse(w, *response)
+		return
+	}
+
+	switch endpoint {
+	case meteologix.EndpointGeocode:
+		writeResponse(w, Response{Body: s.searchLocations(r.URL.Query())})
+	case meteologix.EndpointReverseGeocode:
+		latitude, longitude, err := coordinates([]string{r.URL.Query().Get("lat"), r.URL.Query().Get("lon")})
+		if err != nil {
+			writeResponse(w, ErrorResponse(http.StatusBadRequest))
+			return
+		}
+		writeResponse(w, Response{Body: s.reverseLocation(latitude, longitude)})
+	case meteologix.EndpointStationSearch:
+		writeResponse(w, Response{Body: defaultStations(parameters)})
+	case meteologix.EndpointObservation:
+		writeResponse(w, Response{Body: defaultObservation(parameters)})
+	default:
+		latitude, longitude, err := coordinates(parameters)
+		if err != nil {
+			writeResponse(w, ErrorResponse(http.StatusBadRequest))
+			return
+		}
+		units := r.URL.Query().Get("units")
+		if units == "" {
+			units = string(meteologix.UnitSystemMetric)
+		}
+		switch endpoint {
+		case meteologix.EndpointCurrentWeather:
+			writeResponse(w, Response{Body: defaultCurrentWeather(latitude, longitude, units)})
+		case meteologix.EndpointForecast:
+			writeResponse(w, Response{Body: defaultForecast(latitude, longitude, units, parameters)})
+		default:
+			writeResponse(w, Response{Body: defaultAstronomicalInfo(latitude, longitude)})
+		}
+	}
+}
+
+// searchLocations returns the OSM Nominatim search results for the given query parameters.
+// The "countrycodes" and "limit" parameters are supported
+func (s *Server) searchLocations(parameters url.Values) []map[string]any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	query := strings.ToLower(parameters.Get("q"))
+	countryCodes := parameters.Get("countrycodes")
+	limit, err := strconv.Atoi(parameters.Get("limit"))
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+	results := make([]map[string]any, 0)
+	for i, location := range s.locations {
+		if query == "" || !strings.Contains(strings.ToLower(location.Name), query) {
+			continue
+		}
+		if countryCodes != "" && !strings.Contains(","+countryCodes+",",
+			","+strings.ToLower(location.CountryCode)+",") {
+			continue
+		}
+		if len(results) >= limit {
+			break
+		}
+		results = append(results, locationResult(i, location))
+	}
+	return results
+}
+
+// reverseLocation returns the OSM Nominatim reverse geocoding result for the given
+// coordinates. If no location is within the ReverseGeocodeRadius, an error result is
+// returned, as done by the OSM Nominatim API
+func (s *Server) reverseLocation(latitude, longitude float64) map[string]any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	nearest, nearestDistance := -1, ReverseGeocodeRadius
+	for i, location := range s.locations {
+		distance := math.Hypot(location.Latitude-latitude, location.Longitude-longitude)
+		if distance <= nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+	if nearest < 0 {
+		return map[string]any{"error": "Unable to geocode"}
+	}
+	return locationResult(nearest, s.locations[nearest])
+}
+
+// locationResult returns the OSM Nominatim result for the location with the given index
+func locationResult(index int, location Location) map[string]any {
+	return map[string]any{
+		"place_id":     index + 1,
+		"lat":          strconv.FormatFloat(location.Latitude, 'f', -1, 64),
+		"lon":          strconv.FormatFloat(location.Longitude, 'f', -1, 64),
+		"display_name": location.Name,
+		"importance":   location.Importance,
+		"address": map[string]any{
+			"country":      location.Country,
+			"country_code": strings.ToLower(location.CountryCode),
+			"state":        location.State,
+		},
+	}
+}
+
+// route returns the endpoint and the path parameters for the given URL path
+func route(path string) (meteologix.Endpoint, []string) {
+	switch {
+	case path == GeocoderPath+"/search":
+		return meteologix.EndpointGeocode, nil
+	case path == GeocoderPath+"/reverse":
+		return meteologix.EndpointReverseGeocode, nil
+	case !strings.HasPrefix(path, APIPath+"/"):
+		return meteologix.EndpointUnknown, nil
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, APIPath), "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "current":
+		return meteologix.EndpointCurrentWeather, parts[1:]
+	case len(parts) == 5 && parts[0] == "forecast":
+		return meteologix.EndpointForecast, parts[1:]
+	case len(parts) == 4 && parts[0] == "station" && parts[1] == "search":
+		return meteologix.EndpointStationSearch, parts[2:]
+	case len(parts) == 4 && parts[0] == "station" && parts[2] == "observations" && parts[3] == "latest":
+		return meteologix.EndpointObservation, parts[1:2]
+	case len(parts) == 4 && parts[0] == "tools" && parts[1] == "astronomy":
+		return meteologix.EndpointAstronomy, parts[2:]
+	default:
+		return meteologix.EndpointUnknown, nil
+	}
+}
+
+// coordinates parses the latitude and longitude from the first two path parameters
+func coordinates(parameters []string) (float64, float64, error) {
+	if len(parameters) < 2 {
+		return 0, 0, errors.New("missing coordinates")
+	}
+	latitude, err := strconv.ParseFloat(parameters[0], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	longitude, err := strconv.ParseFloat(parameters[1], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return latitude, longitude, nil
+}
+
+// writeResponse writes the given Response to the http.ResponseWriter
+func writeResponse(w http.ResponseWriter, response Response) {
+	for name, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	contentType := response.ContentType
+	if contentType == "" {
+		contentType = meteologix.MIMETypeJSON
+	}
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	var body []byte
+	switch value := response.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(value)
+	case []byte:
+		body = value
+	default:
+		var err error
+		if body, err = json.Marshal(value); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// apiFloat returns a JSON data point as returned by the API
+func apiFloat(dateTime time.Time, value float64) map[string]any {
+	return map[string]any{"dateTime": dateTime.Format(time.RFC3339), "value": value}
+}
+
+// unitValue returns the metric or imperial value for the given unit system
+func unitValue(units string, metric, imperial float64) float64 {
+	if units == string(meteologix.UnitSystemImperial) {
+		return imperial
+	}
+	return metric
+}
+
+// defaultCurrentWeather returns the default current weather response
+func defaultCurrentWeather(latitude, longitude float64, units string) map[string]any {
+	now := time.Now().UTC().Truncate(time.Minute * 10)
+	return map[string]any{
+		"lat":           latitude,
+		"lon":           longitude,
+		"systemOfUnits": units,
+		"data": map[string]any{
+			"cloudCoverage":    apiFloat(now, 25),
+			"dewpoint":         apiFloat(now, unitValue(units, 9.5, 49.1)),
+			"humidityRelative": apiFloat(now, 62),
+			"isDay":            map[string]any{"dateTime": now.Format(time.RFC3339), "value": true},
+			"prec1h":           apiFloat(now, 0),
+			"pressureMsl":      apiFloat(now, unitValue(units, 1013.2, 29.92)),
+			"temp":             apiFloat(now, unitValue(units, 16.8, 62.24)),
+			"weatherSymbol": map[string]any{
+				"dateTime": now.Format(time.RFC3339),
+				"value":    "partlycloudy",
+			},
+			"windDirection": apiFloat(now, 240),
+			"windGust":      apiFloat(now, unitValue(units, 8.2, 18.34)),
+			"windSpeed":     apiFloat(now, unitValue(units, 3.9, 8.72)),
+		},
+	}
+}
+
+// defaultForecast returns the default weather forecast response
+func defaultForecast(latitude, longitude float64, units string, parameters []string) map[string]any {
+	step := time.Hour
+	if len(parameters) == 4 {
+		if duration, err := time.ParseDuration(parameters[3]); err == nil && duration > 0 {
+			step = duration
+		}
+	}
+	run := time.Now().UTC().Truncate(time.Hour)
+	data := make([]map[string]any, 0)
+	for dateTime := run; dateTime.Before(run.Add(time.Hour * 72)); dateTime = dateTime.Add(step) {
+		data = append(data, map[string]any{
+			"dateTime":         dateTime.Format(time.RFC3339),
+			"cloudCoverage":    40,
+			"dewpoint":         unitValue(units, 8.7, 47.66),
+			"humidityRelative": 70,
+			"isDay":            dateTime.Hour() >= 6 && dateTime.Hour() < 20,
+			"pressureMsl":      unitValue(units, 1012.8, 29.91),
+			"sunHours":         0.5,
+			"temp":             unitValue(units, 15.2, 59.36),
+			"weatherSymbol":    "cloudy",
+			"windDirection":    230,
+			"windGust":         unitValue(units, 7.1, 15.88),
+			"windspeed":        unitValue(units, 3.2, 7.16),
+		})
+	}
+	return map[string]any{
+		"alt":           50,
+		"data":          data,
+		"lat":           latitude,
+		"lon":           longitude,
+		"resolution":    "HIGH",
+		"run":           run.Format(time.RFC3339),
+		"systemOfUnits": units,
+		"timeZone":      "UTC",
+	}
+}
+
+// defaultStations returns the default station search response
+func defaultStations(parameters []string) []map[string]any {
+	latitude, longitude, _ := coordinates(parameters)
+	return []map[string]any{
+		{
+			"alt":            45,
+			"distance":       1.4,
+			"id":             DefaultStationID,
+			"lat":            latitude,
+			"lon":            longitude,
+			"name":           "Fake Station",
+			"precision":      "HIGH",
+			"recentlyActive": true,
+			"type":           "STATION",
+		},
+	}
+}
+
+// defaultObservation returns the default station observation response
+func defaultObservation(parameters []string) map[string]any {
+	stationID := DefaultStationID
+	if len(parameters) > 0 {
+		stationID = parameters[0]
+	}
+	now := time.Now().UTC().Truncate(time.Minute * 10)
+	return map[string]any{
+		"ele":       45,
+		"lat":       50.9833,
+		"lon":       6.9833,
+		"name":      "Fake Station",
+		"stationId": stationID,
+		"data": map[string]any{
+			"dewpoint":         apiFloat(now, 9.1),
+			"humidityRelative": apiFloat(now, 64),
+			"prec1h":           apiFloat(now, 0),
+			"pressureMsl":      apiFloat(now, 1013.4),
+			"temp":             apiFloat(now, 16.3),
+			"windDirection":    apiFloat(now, 250),
+			"windSpeed":        apiFloat(now, 4.1),
+		},
+	}
+}
+
+// defaultAstronomicalInfo returns the default astronomical information response
+func defaultAstronomicalInfo(latitude, longitude float64) map[string]any {
+	today := time.Now().UTC().Truncate(time.Hour * 24)
+	dailyData := make([]map[string]any, 0, 14)
+	for day := 0; day < 14; day++ {
+		date := today.AddDate(0, 0, day)
+		dailyData = append(dailyData, map[string]any{
+			"dateTime":         date.Format(meteologix.DateFormat),
+			"moonIllumination": 50,
+			"moonPhase":        25,
+			"sunrise":          date.Add(time.Hour * 6).Format(time.RFC3339),
+			"sunset":           date.Add(time.Hour * 20).Format(time.RFC3339),
+			"transit":          date.Add(time.Hour * 13).Format(time.RFC3339),
+		})
+	}
+	return map[string]any{
+		"dailyData":    dailyData,
+		"lat":          latitude,
+		"lon":          longitude,
+		"nextFullMoon": today.AddDate(0, 0, 7).Format(time.RFC3339),
+		"nextNewMoon":  today.AddDate(0, 0, 21).Format(time.RFC3339),
+		"run":          today.Format(time.RFC3339),
+		"timeZone":     "UTC",
+	}
+}
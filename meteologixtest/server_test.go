@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologixtest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/wneessen/go-meteologix"
+)
+
+func TestServer_DefaultResponses(t *testing.T) {
+	server := NewTestServer(t)
+	server.AddLocation(Location{Name: "Cologne, North Rhine-Westphalia, Germany", Latitude: 50.938361,
+		Longitude: 6.959974, Importance: 0.8})
+	c := meteologix.New(server.ClientOptions()...)
+
+	cw, err := c.CurrentWeatherByLocation("cologne")
+	if err != nil {
+		t.Errorf("CurrentWeatherByLocation failed: %s", err)
+		return
+	}
+	if cw.Latitude != 50.938361 || cw.Longitude != 6.959974 {
+		t.Errorf("CurrentWeatherByLocation failed, unexpected coordinates: %f/%f", cw.Latitude, cw.Longitude)
+	}
+	if !cw.Temperature().IsAvailable() {
+		t.Errorf("CurrentWeatherByLocation failed, expected temperature to be available")
+	}
+
+	fc, err := c.ForecastByCoordinates(50.9, 6.9, meteologix.Timespan3Hours, meteologix.ForecastDetailStandard)
+	if err != nil {
+		t.Errorf("ForecastByCoordinates failed: %s", err)
+		return
+	}
+	if len(fc.Data) != 24 {
+		t.Errorf("ForecastByCoordinates failed, expected 24 data points, got: %d", len(fc.Data))
+	}
+
+	stations, err := c.StationSearchByCoordinates(50.9, 6.9)
+	if err != nil {
+		t.Errorf("StationSearchByCoordinates failed: %s", err)
+		return
+	}
+	observation, err := c.ObservationLatestByStationID(stations[0].ID)
+	if err != nil {
+		t.Errorf("ObservationLatestByStationID failed: %s", err)
+		return
+	}
+	if observation.StationID != DefaultStationID {
+		t.Errorf("ObservationLatestByStationID failed, expected station ID: %s, got: %s", DefaultStationID,
+			observation.StationID)
+	}
+
+	astroInfo, err := c.AstronomicalInfoByCoordinates(50.9, 6.9)
+	if err != nil {
+		t.Errorf("AstronomicalInfoByCoordinates failed: %s", err)
+		return
+	}
+	if !astroInfo.SunsetByTime(time.Now().UTC()).IsAvailable() {
+		t.Errorf("AstronomicalInfoByCoordinates failed, expected sunset to be available")
+	}
+
+	if _, err = c.GetGeoLocationByName("Nonexisting City"); !errors.Is(err, meteologix.ErrCityNotFound) {
+		t.Errorf("GetGeoLocationByName was expected to fail with ErrCityNotFound, got: %v", err)
+	}
+
+	server.AssertRequestCount(t, meteologix.EndpointGeocode, 2)
+	server.AssertRequestCount(t, meteologix.EndpointCurrentWeather, 1)
+	server.AssertRequestCount(t, meteologix.EndpointForecast, 1)
+	server.AssertRequestCount(t, meteologix.EndpointStationSearch, 1)
+	server.AssertRequestCount(t, meteologix.EndpointObservation, 1)
+	server.AssertRequestCount(t, meteologix.EndpointAstronomy, 1)
+	if units := server.RequestsTo(meteologix.EndpointCurrentWeather)[0].Query.Get("units"); units != "metric" {
+		t.Errorf("expected units query parameter: metric, got: %s", units)
+	}
+}
+
+func TestServer_UnitSystem(t *testing.T) {
+	server := NewTestServer(t)
+	metric := meteologix.New(server.ClientOptions()...)
+	imperial := meteologix.New(append(server.ClientOptions(),
+		meteologix.WithUnitSystem(meteologix.UnitSystemImperial))...)
+	metricWeather, err := metric.CurrentWeatherByCoordinates(50.9833, 6.9833)
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	imperialWeather, err := imperial.CurrentWeatherByCoordinates(50.9833, 6.9833)
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	if imperialWeather.Units() != meteologix.UnitSystemImperial {
+		t.Errorf("expected unit system: %s, got: %s", meteologix.UnitSystemImperial, imperialWeather.Units())
+	}
+	if metricWeather.Temperature().String() != imperialWeather.Temperature().String() {
+		t.Errorf("expected same temperature for both unit systems, got: %s and %s",
+			metricWeather.Temperature(), imperialWeather.Temperature())
+	}
+	if metricWeather.WindSpeed().String() != imperialWeather.WindSpeed().String() {
+		t.Errorf("expected same wind speed for both unit systems, got: %s and %s",
+			metricWeather.WindSpeed(), imperialWeather.WindSpeed())
+	}
+	if metricWeather.PressureMSL().String() != imperialWeather.PressureMSL().String() {
+		t.Errorf("expected same pressure for both unit systems, got: %s and %s",
+			metricWeather.PressureMSL(), imperialWeather.PressureMSL())
+	}
+
+	forecast, err := imperial.ForecastByCoordinates(50.9833, 6.9833, meteologix.Timespan1Hour,
+		meteologix.ForecastDetailStandard)
+	if err != nil {
+		t.Errorf("ForecastByCoordinates failed: %s", err)
+		return
+	}
+	if temperature := forecast.All()[0].Temperature().String(); temperature != "15.2°C" {
+		t.Errorf("expected forecast temperature: %s, got: %s", "15.2°C", temperature)
+	}
+	if units := server.RequestsTo(meteologix.EndpointForecast)[0].Query.Get("units"); units != "imperial" {
+		t.Errorf("expected units query parameter: imperial, got: %s", units)
+	}
+}
+
+func TestServer_InjectError(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Injected response
+		r Response
+		// Expected error
+		e error
+	}{
+		{"HTTP 401", ErrorResponse(http.StatusUnauthorized), meteologix.ErrUnauthorized},
+		{"HTTP 429", ErrorResponse(http.StatusTooManyRequests), meteologix.ErrRateLimited},
+		{"HTTP 503", ErrorResponse(http.StatusServiceUnavailable), meteologix.ErrUpstreamUnavailable},
+		{"Non-JSON", NonJSONResponse(http.StatusBadGateway), meteologix.ErrNonJSONResponse},
+	}
+	server := NewTestServer(t)
+	c := meteologix.New(server.ClientOptions()...)
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			server.InjectError(meteologix.EndpointCurrentWeather, 1, tc.r)
+			if _, err := c.CurrentWeatherByCoordinates(50.9, 6.9); !errors.Is(err, tc.e) {
+				t.Errorf("CurrentWeatherByCoordinates was expected to fail with %q, got: %v", tc.e, err)
+			}
+			if _, err := c.CurrentWeatherByCoordinates(50.9, 6.9); err != nil {
+				t.Errorf("CurrentWeatherByCoordinates was expected to succeed after injected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestServer_SetResponseAndAuth(t *testing.T) {
+	server := NewTestServer(t)
+	server.RequireAPIKey("API-KEY")
+	server.SetResponse(meteologix.EndpointObservation, Response{
+		Body: `{"stationId":"custom","name":"Custom","data":{}}`,
+	})
+
+	c := meteologix.New(server.ClientOptions()...)
+	if _, err := c.ObservationLatestByStationID("custom"); !errors.Is(err, meteologix.ErrUnauthorized) {
+		t.Errorf("ObservationLatestByStationID was expected to fail with ErrUnauthorized, got: %v", err)
+	}
+
+	c = meteologix.New(append(server.ClientOptions(), meteologix.WithAPIKey("API-KEY"))...)
+	observation, err := c.ObservationLatestByStationID("custom")
+	if err != nil {
+		t.Errorf("ObservationLatestByStationID failed: %s", err)
+		return
+	}
+	if observation.Name != "Custom" {
+		t.Errorf("ObservationLatestByStationID failed, expected name: Custom, got: %s", observation.Name)
+	}
+	server.AssertHeader(t, meteologix.EndpointObservation, "X-API-Key", "API-KEY")
+
+	server.Reset()
+	if len(server.Requests()) != 0 {
+		t.Errorf("Reset failed, expected no recorded requests, got: %d", len(server.Requests()))
+	}
+}
+
+func TestServer_ReverseGeocoding(t *testing.T) {
+	server := NewTestServer(t)
+	server.AddLocation(Location{Name: "Cologne, North Rhine-Westphalia, Germany", Latitude: 50.938361,
+		Longitude: 6.959974, Importance: 0.8, State: "North Rhine-Westphalia", Country: "Germany"})
+	c := meteologix.New(server.ClientOptions()...)
+
+	cw, err := c.CurrentWeatherByCoordinates(50.9, 6.9, meteologix.WithCallReverseGeocoding())
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	if cw.Location == nil || cw.Location.Address.Country != "Germany" {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected location in Germany, got: %+v", cw.Location)
+	}
+	observation, err := c.ObservationLatestByStationID(DefaultStationID, meteologix.WithCallReverseGeocoding())
+	if err != nil {
+		t.Errorf("ObservationLatestByStationID failed: %s", err)
+		return
+	}
+	if observation.Location == nil || observation.Location.Address.State != "North Rhine-Westphalia" {
+		t.Errorf("ObservationLatestByStationID failed, expected location in North Rhine-Westphalia, got: %+v",
+			observation.Location)
+	}
+
+	// Coordinates without a known location are not annotated
+	cw, err = c.CurrentWeatherByCoordinates(-45, 120, meteologix.WithCallReverseGeocoding())
+	if err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+		return
+	}
+	if cw.Location != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed, expected no location, got: %+v", cw.Location)
+	}
+	if _, err = c.CurrentWeatherByCoordinates(50.9, 6.9); err != nil {
+		t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+	}
+	server.AssertRequestCount(t, meteologix.EndpointReverseGeocode, 3)
+}
+
+func TestServer_GeoSearchOptions(t *testing.T) {
+	server := NewTestServer(t)
+	server.AddLocation(Location{Name: "Springfield, Illinois, United States", Latitude: 39.799,
+		Longitude: -89.644, Importance: 0.7, CountryCode: "US"})
+	server.AddLocation(Location{Name: "Springfield, Dunedin, New Zealand", Latitude: -45.86,
+		Longitude: 170.50, Importance: 0.3, CountryCode: "NZ"})
+	c := meteologix.New(server.ClientOptions()...)
+
+	locations, err := c.GetGeoLocationsByName("springfield",
+		meteologix.WithCallGeoSearch(meteologix.GeoSearchOptions{CountryCodes: []string{"nz"}}))
+	if err != nil {
+		t.Errorf("GetGeoLocationsByName failed: %s", err)
+		return
+	}
+	if len(locations) != 1 || locations[0].Address.CountryCode != "nz" {
+		t.Errorf("GetGeoLocationsByName failed, expected 1 location in nz, got: %+v", locations)
+	}
+	locations, err = c.GetGeoLocationsByName("springfield",
+		meteologix.WithCallGeoSearch(meteologix.GeoSearchOptions{Limit: 1}))
+	if err != nil {
+		t.Errorf("GetGeoLocationsByName failed: %s", err)
+		return
+	}
+	if len(locations) != 1 {
+		t.Errorf("GetGeoLocationsByName failed, expected 1 location, got: %d", len(locations))
+	}
+}
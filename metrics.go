@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsContentType is the content type of the Prometheus text exposition format
+const MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// MetricsErrorCodeTransport is the code label value for requests that failed without
+// a HTTP response (e.g. network errors)
+const MetricsErrorCodeTransport = "transport"
+
+// MetricsErrorCodeOther is the code label value for requests that failed for other
+// reasons than an APIError (e.g. a non-JSON response)
+const MetricsErrorCodeOther = "other"
+
+// DefaultMetricsBuckets are the default upper bounds (in seconds) of the request latency
+// histogram buckets
+var DefaultMetricsBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics collects usage metrics of the Client per Endpoint. This includes the number of
+// requests sent to the upstream APIs, errors by status code, request latencies and cache
+// hits and misses.
+//
+// Metrics satisfies the http.Handler interface and exposes the collected metrics in the
+// Prometheus text exposition format. It is safe for concurrent use.
+type Metrics struct {
+	// buckets holds the upper bounds of the latency histogram buckets
+	buckets []float64
+	// cacheHits holds the number of cache hits per Endpoint
+	cacheHits map[Endpoint]uint64
+	// cacheMisses holds the number of cache misses per Endpoint
+	cacheMisses map[Endpoint]uint64
+	// errors holds the number of failed requests per Endpoint and code
+	errors map[metricsErrorKey]uint64
+	// latencies holds the latency histogram per Endpoint
+	latencies map[Endpoint]*metricsHistogram
+	// mutex protects the Metrics
+	mutex sync.Mutex
+	// requests holds the number of requests per Endpoint
+	requests map[Endpoint]uint64
+}
+
+// metricsErrorKey is the map key for the error counters of the Metrics
+type metricsErrorKey struct {
+	code     string
+	endpoint Endpoint
+}
+
+// metricsHistogram is a latency histogram of the Metrics
+type metricsHistogram struct {
+	count  uint64
+	counts []uint64
+	sum    float64
+}
+
+// NewMetrics returns a new Metrics collector with the DefaultMetricsBuckets
+func NewMetrics() *Metrics {
+	return NewMetricsWithBuckets(DefaultMetricsBuckets)
+}
+
+// NewMetricsWithBuckets returns a new Metrics collector with the given latency histogram
+// bucket upper bounds (in seconds)
+func NewMetricsWithBuckets(buckets []float64) *Metrics {
+	sortedBuckets := make([]float64, len(buckets))
+	copy(sortedBuckets, buckets)
+	sort.Float64s(sortedBuckets)
+	return &Metrics{
+		buckets:     sortedBuckets,
+		cacheHits:   make(map[Endpoint]uint64),
+		cacheMisses: make(map[Endpoint]uint64),
+		errors:      make(map[metricsErrorKey]uint64),
+		latencies:   make(map[Endpoint]*metricsHistogram),
+		requests:    make(map[Endpoint]uint64),
+	}
+}
+
+// Requests returns the number of requests that have been sent for the given Endpoint
+func (m *Metrics) Requests(endpoint Endpoint) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.requests[endpoint]
+}
+
+// Errors returns the number of failed requests for the given Endpoint and code label
+func (m *Metrics) Errors(endpoint Endpoint, code string) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.errors[metricsErrorKey{code: code, endpoint: endpoint}]
+}
+
+// CacheHits returns the number of cache hits for the given Endpoint
+func (m *Metrics) CacheHits(endpoint Endpoint) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.cacheHits[endpoint]
+}
+
+// CacheMisses returns the number of cache misses for the given Endpoint
+func (m *Metrics) CacheMisses(endpoint Endpoint) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.cacheMisses[endpoint]
+}
+
+// ServeHTTP satisfies the http.Handler interface for the Metrics type
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", MetricsContentType)
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the collected metrics in the Prometheus text exposition format
+// to the given io.Writer
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	output := &strings.Builder{}
+	writeCounter(output, "meteologix_requests_total",
+		"Total number of HTTP requests sent to the upstream APIs.", m.requests)
+
+	output.WriteString("# HELP meteologix_request_errors_total Total number of failed HTTP requests by code.\n")
+	output.WriteString("# TYPE meteologix_request_errors_total counter\n")
+	errorKeys := make([]metricsErrorKey, 0, len(m.errors))
+	for key := range m.errors {
+		errorKeys = append(errorKeys, key)
+	}
+	sort.Slice(errorKeys, func(i, j int) bool {
+		if errorKeys[i].endpoint != errorKeys[j].endpoint {
+			return errorKeys[i].endpoint < errorKeys[j].endpoint
+		}
+		return errorKeys[i].code < errorKeys[j].code
+	})
+	for _, key := range errorKeys {
+		fmt.Fprintf(output, "meteologix_request_errors_total{endpoint=%q,code=%q} %d\n", key.endpoint,
+			key.code, m.errors[key])
+	}
+
+	output.WriteString("# HELP meteologix_request_duration_seconds Latency of the HTTP requests in seconds.\n")
+	output.WriteString("# TYPE meteologix_request_duration_seconds histogram\n")
+	for _, endpoint := range sortedEndpoints(m.latencies) {
+		histogram := m.latencies[endpoint]
+		for i, bucket := range m.buckets {
+			fmt.Fprintf(output, "meteologix_request_duration_seconds_bucket{endpoint=%q,le=%q} %d\n",
+				endpoint, strconv.FormatFloat(bucket, 'g', -1, 64), histogram.counts[i])
+		}
+		fmt.Fprintf(output, "meteologix_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n",
+			endpoint, histogram.count)
+		fmt.Fprintf(output, "meteologix_request_duration_seconds_sum{endpoint=%q} %s\n", endpoint,
+			strconv.FormatFloat(histogram.sum, 'g', -1, 64))
+		fmt.Fprintf(output, "meteologix_request_duration_seconds_count{endpoint=%q} %d\n", endpoint,
+			histogram.count)
+	}
+
+	writeCounter(output, "meteologix_cache_hits_total",
+		"Total number of API responses served from the cache.", m.cacheHits)
+	writeCounter(output, "meteologix_cache_misses_total",
+		"Total number of API responses not found in the cache.", m.cacheMisses)
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+// observeRequest records a request for the given Endpoint with its latency and the
+// resulting error (if any)
+func (m *Metrics) observeRequest(endpoint Endpoint, duration time.Duration, response *http.Response, err error) {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests[endpoint]++
+	histogram, ok := m.latencies[endpoint]
+	if !ok {
+		histogram = &metricsHistogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[endpoint] = histogram
+	}
+	seconds := duration.Seconds()
+	for i, bucket := range m.buckets {
+		if seconds <= bucket {
+			histogram.counts[i]++
+		}
+	}
+	histogram.count++
+	histogram.sum += seconds
+
+	if err == nil {
+		return
+	}
+	code := MetricsErrorCodeOther
+	var apiError APIError
+	switch {
+	case errors.As(err, &apiError):
+		code = strconv.Itoa(apiError.Code)
+	case response == nil:
+		code = MetricsErrorCodeTransport
+	}
+	m.errors[metricsErrorKey{code: code, endpoint: endpoint}]++
+}
+
+// observeCache records a cache hit or miss for the given Endpoint
+func (m *Metrics) observeCache(endpoint Endpoint, hit bool) {
+	if m == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if hit {
+		m.cacheHits[endpoint]++
+		return
+	}
+	m.cacheMisses[endpoint]++
+}
+
+// writeCounter writes a counter metric with an endpoint label in the Prometheus text
+// exposition format to the given strings.Builder
+func writeCounter(output *strings.Builder, name, help string, values map[Endpoint]uint64) {
+	fmt.Fprintf(output, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(output, "# TYPE %s counter\n", name)
+	for _, endpoint := range sortedEndpoints(values) {
+		fmt.Fprintf(output, "%s{endpoint=%q} %d\n", name, endpoint, values[endpoint])
+	}
+}
+
+// sortedEndpoints returns the sorted Endpoint keys of the given map
+func sortedEndpoints[T any](values map[Endpoint]T) []Endpoint {
+	endpoints := make([]Endpoint, 0, len(values))
+	for endpoint := range values {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i] < endpoints[j] })
+	return endpoints
+}
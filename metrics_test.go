@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		if strings.HasPrefix(r.URL.Path, "/v02/forecast/") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"detail":"forbidden"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"lat":50.9833,"lon":6.9833,"data":{}}`))
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	c := New(WithAPIBaseURL(server.URL+"/v02"), WithMetrics(metrics), WithCache(NewMemoryCache(10)))
+	for i := 0; i < 2; i++ {
+		if _, err := c.CurrentWeatherByCoordinates(50.9833, 6.9833); err != nil {
+			t.Errorf("CurrentWeatherByCoordinates failed: %s", err)
+			return
+		}
+	}
+	if _, err := c.ForecastByCoordinates(50.9833, 6.9833, Timespan1Hour, ForecastDetailStandard); err == nil {
+		t.Errorf("ForecastByCoordinates was supposed to fail, but didn't")
+	}
+
+	if r := metrics.Requests(EndpointCurrentWeather); r != 1 {
+		t.Errorf("Metrics failed, expected 1 current weather request, got: %d", r)
+	}
+	if r := metrics.Requests(EndpointForecast); r != 1 {
+		t.Errorf("Metrics failed, expected 1 forecast request, got: %d", r)
+	}
+	if e := metrics.Errors(EndpointForecast, "403"); e != 1 {
+		t.Errorf("Metrics failed, expected 1 forecast error with code 403, got: %d", e)
+	}
+	if h := metrics.CacheHits(EndpointCurrentWeather); h != 1 {
+		t.Errorf("Metrics failed, expected 1 current weather cache hit, got: %d", h)
+	}
+	if m := metrics.CacheMisses(EndpointCurrentWeather); m != 1 {
+		t.Errorf("Metrics failed, expected 1 current weather cache miss, got: %d", m)
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := recorder.Header().Get("Content-Type"); ct != MetricsContentType {
+		t.Errorf("Metrics ServeHTTP failed, expected content type: %s, got: %s", MetricsContentType, ct)
+	}
+	body := recorder.Body.String()
+	for _, e := range []string{
+		"# TYPE meteologix_requests_total counter",
+		`meteologix_requests_total{endpoint="current"} 1`,
+		`meteologix_request_errors_total{endpoint="forecast",code="403"} 1`,
+		`meteologix_request_duration_seconds_bucket{endpoint="current",le="+Inf"} 1`,
+		`meteologix_request_duration_seconds_count{endpoint="forecast"} 1`,
+		`meteologix_cache_hits_total{endpoint="current"} 1`,
+		`meteologix_cache_misses_total{endpoint="forecast"} 1`,
+	} {
+		if !strings.Contains(body, e) {
+			t.Errorf("Metrics ServeHTTP failed, expected output to contain %q, got: %s", e, body)
+		}
+	}
+}
+
+func TestMetrics_observeRequest(t *testing.T) {
+	metrics := NewMetricsWithBuckets([]float64{1, 0.1})
+	metrics.observeRequest(EndpointGeocode, 0, nil, http.ErrHandlerTimeout)
+	metrics.observeRequest(EndpointGeocode, 0, &http.Response{}, ErrNonJSONResponse)
+	if e := metrics.Errors(EndpointGeocode, MetricsErrorCodeTransport); e != 1 {
+		t.Errorf("Metrics failed, expected 1 transport error, got: %d", e)
+	}
+	if e := metrics.Errors(EndpointGeocode, MetricsErrorCodeOther); e != 1 {
+		t.Errorf("Metrics failed, expected 1 other error, got: %d", e)
+	}
+	output := &strings.Builder{}
+	if err := metrics.WritePrometheus(output); err != nil {
+		t.Errorf("Metrics WritePrometheus failed: %s", err)
+		return
+	}
+	if !strings.Contains(output.String(), `meteologix_request_duration_seconds_bucket{endpoint="geocode",le="0.1"} 2`) {
+		t.Errorf("Metrics WritePrometheus failed, expected sorted buckets, got: %s", output.String())
+	}
+
+	var nilMetrics *Metrics
+	nilMetrics.observeRequest(EndpointGeocode, 0, nil, nil)
+	nilMetrics.observeCache(EndpointGeocode, true)
+}
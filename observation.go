@@ -5,8 +5,9 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"time"
 )
 
 // ErrUnsupportedDirection is returned when a direction degree is given, that is not resolvable
@@ -22,6 +23,9 @@ type Observation struct {
 	Name string `json:"name"`
 	// Latitude represents the GeoLocation latitude coordinates for the Station
 	Latitude float64 `json:"lat"`
+	// Location is the reverse geocoded GeoLocation of the Station coordinates. It is only set
+	// if requested via WithCallReverseGeocoding and a location was found for the coordinates
+	Location *GeoLocation `json:"-"`
 	// Longitude represents the GeoLocation longitude coordinates for the Station
 	Longitude float64 `json:"lon"`
 	// StationID is the ID of the Station providing the Observation
@@ -83,17 +87,32 @@ type APIObservationData struct {
 }
 
 // ObservationLatestByStationID returns the latest Observation values from the given Station
-func (c *Client) ObservationLatestByStationID(stationID string) (Observation, error) {
+func (c *Client) ObservationLatestByStationID(stationID string, options ...CallOption) (Observation, error) {
+	return c.ObservationLatestByStationIDContext(context.Background(), stationID, options...)
+}
+
+// ObservationLatestByStationIDContext returns the latest Observation values from the given
+// Station using the provided context for the API request
+func (c *Client) ObservationLatestByStationIDContext(ctx context.Context, stationID string,
+	options ...CallOption,
+) (Observation, error) {
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
+
 	var observation Observation
 	apiURL := fmt.Sprintf("%s/station/%s/observations/latest", c.config.apiURL, stationID)
-	response, err := c.httpClient.Get(apiURL)
+	response, cached, err := c.getCached(ctx, call, apiURL)
 	if err != nil {
 		return observation, fmt.Errorf("API request failed: %w", err)
 	}
 
-	if err = json.Unmarshal(response, &observation); err != nil {
-		return observation, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = c.httpClient.unmarshalResponse(ctx, apiURL, response, &observation); err != nil {
+		return observation, err
 	}
+	if !cached {
+		c.setCached(call, apiURL, response, cacheTTL(observation.latestDateTime(), DefaultCacheTTLObservation))
+	}
+	observation.Location = c.reverseGeoLocation(ctx, call, observation.Latitude, observation.Longitude)
 
 	return observation, nil
 }
@@ -102,16 +121,33 @@ func (c *Client) ObservationLatestByStationID(stationID string) (Observation, er
 // nearby weather stations (25 km radius) and returns the latest Observation values from the
 // Stations with the shortest distance. It will also return the Station that was used for the query.
 // It will throw an error if no station could be found in that queried location.
-func (c *Client) ObservationLatestByLocation(location string) (Observation, Station, error) {
-	stations, err := c.StationSearchByLocationWithinRadius(location, 25)
+func (c *Client) ObservationLatestByLocation(location string, options ...CallOption) (Observation, Station, error) {
+	return c.ObservationLatestByLocationContext(context.Background(), location, options...)
+}
+
+// ObservationLatestByLocationContext performs the same lookups as ObservationLatestByLocation
+// using the provided context for all API requests
+func (c *Client) ObservationLatestByLocationContext(ctx context.Context, location string,
+	options ...CallOption,
+) (Observation, Station, error) {
+	ctx, cancel, _ := c.callContext(ctx, options)
+	defer cancel()
+
+	stations, err := c.StationSearchByLocationWithinRadiusContext(ctx, location, 25, options...)
 	if err != nil {
 		return Observation{}, Station{}, fmt.Errorf("failed search locations at given location: %w", err)
 	}
 	station := stations[0]
-	observation, err := c.ObservationLatestByStationID(station.ID)
+	observation, err := c.ObservationLatestByStationIDContext(ctx, station.ID, options...)
 	return observation, station, err
 }
 
+// latestDateTime returns the timestamp of the most recent data point of the Observation
+func (o Observation) latestDateTime() time.Time {
+	return latestDateTime(o.Data.Temperature, o.Data.Dewpoint, o.Data.HumidityRelative,
+		o.Data.PressureMSL, o.Data.PressureQFE, o.Data.Precipitation, o.Data.WindSpeed)
+}
+
 // Dewpoint returns the dewpoint data point as Temperature
 //
 // If the data point is not available in the Observation it will return Temperature in which the
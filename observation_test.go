@@ -29,7 +29,7 @@ func TestClient_ObservationLatestByStationID_Mock(t *testing.T) {
 		{"All data fields", "all", 123, 1.234, -1.234},
 		{"No data fields", "none", 123, 1.234, -1.234},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -61,7 +61,7 @@ func TestClient_ObservationLatestByStationID_Mock(t *testing.T) {
 }
 
 func TestClient_ObservationLatestByStationID_MockFail(t *testing.T) {
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -152,7 +152,7 @@ func TestClient_ObservationLatestByStationID_Dewpoint(t *testing.T) {
 		{"No data fields", "none", nil},
 	}
 
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -204,7 +204,7 @@ func TestClient_ObservationLatestByStationID_DewpointMean(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 8.3}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -261,7 +261,7 @@ func TestClient_ObservationLatestByStationID_HumidityRealtive(t *testing.T) {
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -326,7 +326,7 @@ func TestClient_ObservationLatestByStationID_PrecipitationCurrent(t *testing.T)
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -384,7 +384,7 @@ func TestClient_ObservationLatestByStationID_Precipitation10m(t *testing.T) {
 		{"All data fields", "all", &Precipitation{floatVal: 0.5}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -433,7 +433,7 @@ func TestClient_ObservationLatestByStationID_Precipitation1h(t *testing.T) {
 		{"All data fields", "all", &Precipitation{floatVal: 10.3}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -482,7 +482,7 @@ func TestClient_ObservationLatestByStationID_Precipitation24h(t *testing.T) {
 		{"All data fields", "all", &Precipitation{floatVal: 32.12}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -518,7 +518,7 @@ func TestClient_ObservationLatestByStationID_Precipitation24h(t *testing.T) {
 }
 
 func TestClient_ObservationLatestByStationID_PrecipitationUnknown(t *testing.T) {
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -553,7 +553,7 @@ func TestClient_ObservationLatestByStationID_Temperature(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 10.8}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -605,7 +605,7 @@ func TestClient_ObservationLatestByStationID_TemperatureAtGround(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 15.4}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -653,7 +653,7 @@ func TestClient_ObservationLatestByStationID_TemperatureMin(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 6.2}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -701,7 +701,7 @@ func TestClient_ObservationLatestByStationID_TemperatureMax(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 12.4}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -749,7 +749,7 @@ func TestClient_ObservationLatestByStationID_TemperatureAtGroundMin(t *testing.T
 		{"All data fields", "all", &Temperature{floatVal: 3.7}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -797,7 +797,7 @@ func TestClient_ObservationLatestByStationID_TemperatureMean(t *testing.T) {
 		{"All data fields", "all", &Temperature{floatVal: 16.3}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -851,7 +851,7 @@ func TestClient_ObservationLatestByStationID_PressureMSL(t *testing.T) {
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -907,7 +907,7 @@ func TestClient_ObservationLatestByStationID_PressureQFE(t *testing.T) {
 		{"All data fields", "all", &Pressure{floatVal: 1020.9}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -942,7 +942,7 @@ func TestClient_ObservationLatestByStationID_PressureQFE(t *testing.T) {
 }
 
 func TestClient_ObservationLatestByStationID_GlobalRadiationCurrent(t *testing.T) {
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -983,7 +983,7 @@ func TestClient_ObservationLatestByStationID_GlobalRadiation10m(t *testing.T) {
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -1040,7 +1040,7 @@ func TestClient_ObservationLatestByStationID_GlobalRadiation1h(t *testing.T) {
 		{"All data fields", "all", &Radiation{floatVal: 200}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -1089,7 +1089,7 @@ func TestClient_ObservationLatestByStationID_GlobalRadiation24h(t *testing.T) {
 		{"All data fields", "all", &Radiation{floatVal: 756}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -1141,7 +1141,7 @@ func TestClient_ObservationLatestByStationID_WindDirection(t *testing.T) {
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -1200,7 +1200,7 @@ func TestClient_ObservationLatestByStationID_WindSpeed(t *testing.T) {
 		}},
 		{"No data fields", "none", nil},
 	}
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "observation"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -10,6 +10,9 @@ import (
 	"time"
 )
 
+// MultiplierInch is the multiplier for converting the base unit (millimeters) to inches
+const MultiplierInch = 0.03937007874
+
 // Precipitation is a type wrapper of an WeatherData for holding precipitation values in WeatherData
 type Precipitation WeatherData
 
@@ -44,3 +47,18 @@ func (p Precipitation) Value() float64 {
 	}
 	return p.floatVal
 }
+
+// Inch returns the Precipitation value in inches
+//
+// If the Precipitation is not available in the WeatherData, Inch will return math.NaN instead.
+func (p Precipitation) Inch() float64 {
+	if p.notAvailable {
+		return math.NaN()
+	}
+	return p.floatVal * MultiplierInch
+}
+
+// InchString returns the Precipitation value as formatted string in inches
+func (p Precipitation) InchString() string {
+	return fmt.Sprintf("%.2fin", p.Inch())
+}
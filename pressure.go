@@ -10,6 +10,9 @@ import (
 	"time"
 )
 
+// MultiplierInHg is the multiplier for converting the base unit (hectopascal) to inches of mercury
+const MultiplierInHg = 0.02952998057
+
 // Pressure is a type wrapper of an WeatherData for holding pressure values in WeatherData
 type Pressure WeatherData
 
@@ -44,3 +47,18 @@ func (p Pressure) Value() float64 {
 	}
 	return p.floatVal
 }
+
+// InHg returns the Pressure value in inches of mercury
+//
+// If the Pressure is not available in the WeatherData, InHg will return math.NaN instead.
+func (p Pressure) InHg() float64 {
+	if p.notAvailable {
+		return math.NaN()
+	}
+	return p.floatVal * MultiplierInHg
+}
+
+// InHgString returns the Pressure value as formatted string in inches of mercury
+func (p Pressure) InHgString() string {
+	return fmt.Sprintf("%.2finHg", p.InHg())
+}
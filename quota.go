@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quotaResetEpochThreshold is the value above which a rate-limit reset header value is
+// interpreted as a Unix timestamp instead of a number of seconds
+const quotaResetEpochThreshold = 1_000_000_000
+
+// List of HTTP headers that hold the rate-limit/quota state of the API subscription. Both,
+// the common X-RateLimit-* headers and the standardized RateLimit-* headers are supported
+var (
+	quotaLimitHeaders     = []string{"X-RateLimit-Limit", "RateLimit-Limit"}
+	quotaRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+	quotaResetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+)
+
+// Quota represents the latest known rate-limit/quota state of the API subscription, as
+// returned by the Meteologix API in the response headers
+type Quota struct {
+	// Limit is the maximum number of API calls within the current quota window. If the
+	// API did not return a limit, Limit is -1
+	Limit int
+	// Remaining is the number of API calls that remain within the current quota window.
+	// If the API did not return the remaining calls, Remaining is -1
+	Remaining int
+	// Reset is the time at which the current quota window resets. If the API did not
+	// return a reset time, Reset is the zero time
+	Reset time.Time
+	// Updated is the time at which the Quota was received from the API
+	Updated time.Time
+}
+
+// QuotaCallback is a function that is called by the HTTPClient whenever an API response
+// with rate-limit/quota headers has been received
+type QuotaCallback func(quota Quota)
+
+// quotaState holds the latest Quota received from the API
+type quotaState struct {
+	mutex sync.RWMutex
+	quota Quota
+}
+
+// newQuotaState returns a new quotaState that holds a not available Quota with unknown
+// limit and remaining calls
+func newQuotaState() *quotaState {
+	return &quotaState{quota: Quota{Limit: -1, Remaining: -1}}
+}
+
+// IsAvailable returns true if a Quota has been received from the API
+func (q Quota) IsAvailable() bool {
+	return !q.Updated.IsZero()
+}
+
+// Quota returns the latest rate-limit/quota state of the API subscription, as returned in
+// the headers of the most recent API response. If no API response with quota headers has
+// been received yet, the returned Quota is not available
+func (c *Client) Quota() Quota {
+	return c.httpClient.quota.get()
+}
+
+// get returns the latest Quota
+func (s *quotaState) get() Quota {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.quota
+}
+
+// update parses the quota headers of the given response and stores the resulting Quota.
+// If the response holds no quota headers, false is returned
+func (s *quotaState) update(response *http.Response) (Quota, bool) {
+	quota, ok := parseQuota(response.Header, time.Now())
+	if !ok {
+		return quota, false
+	}
+	s.mutex.Lock()
+	s.quota = quota
+	s.mutex.Unlock()
+	return quota, true
+}
+
+// parseQuota parses the rate-limit/quota headers of the given HTTP header. If none of the
+// headers are present, false is returned
+func parseQuota(header http.Header, now time.Time) (Quota, bool) {
+	quota := Quota{Limit: -1, Remaining: -1, Updated: now}
+	found := false
+	if value, ok := quotaHeaderInt(header, quotaLimitHeaders); ok {
+		quota.Limit = int(value)
+		found = true
+	}
+	if value, ok := quotaHeaderInt(header, quotaRemainingHeaders); ok {
+		quota.Remaining = int(value)
+		found = true
+	}
+	if value, ok := quotaHeaderInt(header, quotaResetHeaders); ok {
+		if value >= quotaResetEpochThreshold {
+			quota.Reset = time.Unix(value, 0)
+		} else {
+			quota.Reset = now.Add(time.Duration(value) * time.Second)
+		}
+		found = true
+	}
+	return quota, found
+}
+
+// quotaHeaderInt returns the integer value of the first of the given headers that is present
+// and holds a valid non-negative integer. Values with multiple policies (e.g. "100, 100;w=60")
+// are reduced to their first value
+func quotaHeaderInt(header http.Header, names []string) (int64, bool) {
+	for _, name := range names {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		value, _, _ = strings.Cut(value, ",")
+		value, _, _ = strings.Cut(value, ";")
+		number, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || number < 0 {
+			continue
+		}
+		return number, true
+	}
+	return 0, false
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseQuota(t *testing.T) {
+	now := time.Date(2023, 5, 28, 12, 0, 0, 0, time.UTC)
+	tt := []struct {
+		// Test name
+		n string
+		// Response headers
+		h map[string]string
+		// Expected limit
+		l int
+		// Expected remaining calls
+		r int
+		// Expected reset time
+		rs time.Time
+		// Expected to be found
+		f bool
+	}{
+		{
+			"X-RateLimit with epoch reset", map[string]string{
+				"X-RateLimit-Limit": "1000", "X-RateLimit-Remaining": "998", "X-RateLimit-Reset": "1685282400",
+			}, 1000, 998, time.Unix(1685282400, 0), true,
+		},
+		{
+			"RateLimit with delta reset", map[string]string{
+				"RateLimit-Limit": "100, 100;w=60", "RateLimit-Remaining": "42", "RateLimit-Reset": "30",
+			}, 100, 42, now.Add(time.Second * 30), true,
+		},
+		{"Remaining only", map[string]string{"X-RateLimit-Remaining": "5"}, -1, 5, time.Time{}, true},
+		{"Invalid values", map[string]string{"X-RateLimit-Remaining": "many"}, -1, -1, time.Time{}, false},
+		{"No headers", map[string]string{}, -1, -1, time.Time{}, false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tc.h {
+				header.Set(key, value)
+			}
+			quota, ok := parseQuota(header, now)
+			if ok != tc.f {
+				t.Errorf("parseQuota failed, expected found: %t, got: %t", tc.f, ok)
+			}
+			if quota.Limit != tc.l {
+				t.Errorf("parseQuota failed, expected limit: %d, got: %d", tc.l, quota.Limit)
+			}
+			if quota.Remaining != tc.r {
+				t.Errorf("parseQuota failed, expected remaining: %d, got: %d", tc.r, quota.Remaining)
+			}
+			if !quota.Reset.Equal(tc.rs) {
+				t.Errorf("parseQuota failed, expected reset: %s, got: %s", tc.rs, quota.Reset)
+			}
+		})
+	}
+}
+
+func TestClient_Quota(t *testing.T) {
+	remaining := 10
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remaining--
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var callbackQuota Quota
+	c := New(WithAPIBaseURL(server.URL+"/v02"), WithGeocoderURL(server.URL+"/osm"),
+		WithQuotaCallback(func(quota Quota) { callbackQuota = quota }))
+	if c.Quota().IsAvailable() {
+		t.Errorf("Client Quota failed, expected no Quota to be available before the first request")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/v02/current"); err != nil {
+			t.Errorf("HTTPClient GetWithContext failed: %s", err)
+			return
+		}
+	}
+	quota := c.Quota()
+	if !quota.IsAvailable() {
+		t.Errorf("Client Quota failed, expected Quota to be available")
+	}
+	if quota.Limit != 10 || quota.Remaining != 8 {
+		t.Errorf("Client Quota failed, expected limit/remaining: 10/8, got: %d/%d", quota.Limit,
+			quota.Remaining)
+	}
+	if callbackQuota != quota {
+		t.Errorf("Client Quota failed, expected QuotaCallback to receive: %+v, got: %+v", quota, callbackQuota)
+	}
+
+	// Responses of the geocoder do not affect the Meteologix API quota
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/osm/search"); err != nil {
+		t.Errorf("HTTPClient GetWithContext failed: %s", err)
+		return
+	}
+	if c.Quota().Remaining != 8 {
+		t.Errorf("Client Quota failed, expected remaining: 8, got: %d", c.Quota().Remaining)
+	}
+}
+
+func TestClient_Quota_BeforeRequest(t *testing.T) {
+	c := New()
+	quota := c.Quota()
+	if quota.IsAvailable() {
+		t.Errorf("Client Quota failed, expected no Quota to be available before the first request")
+	}
+	if quota.Limit != -1 || quota.Remaining != -1 {
+		t.Errorf("Client Quota failed, expected limit/remaining: -1/-1, got: %d/%d", quota.Limit,
+			quota.Remaining)
+	}
+	if !quota.Reset.IsZero() {
+		t.Errorf("Client Quota failed, expected zero reset time, got: %s", quota.Reset)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultGeocoderRateLimit is the default number of requests per second that are sent to
+// the public OSM Nominatim API. The Nominatim usage policy allows an absolute maximum of
+// 1 request per second.
+//
+// See: https://operations.osmfoundation.org/policies/nominatim/
+const DefaultGeocoderRateLimit = 1
+
+// RateLimiter is a token-bucket rate limiter that is used to throttle the requests that
+// are sent to an upstream API. It is safe for concurrent use.
+type RateLimiter struct {
+	// burst is the maximum number of tokens in the bucket
+	burst float64
+	// last is the time the bucket was last refilled
+	last time.Time
+	// mutex protects the token bucket
+	mutex sync.Mutex
+	// rate is the number of tokens that are added to the bucket per second
+	rate float64
+	// tokens is the number of currently available tokens. A negative value represents
+	// reservations of waiting callers
+	tokens float64
+}
+
+// NewRateLimiter returns a new RateLimiter that allows the given number of requests per
+// second with bursts of up to burst requests. A burst value smaller than 1 is treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		burst:  float64(burst),
+		last:   time.Now(),
+		rate:   requestsPerSecond,
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until the RateLimiter permits a request or the given context is done. If the
+// required waiting time exceeds the deadline of the context, Wait returns immediately with
+// an error wrapping context.DeadlineExceeded.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		l.release()
+		return fmt.Errorf("rate limit delay of %s exceeds context deadline: %w", delay,
+			context.DeadlineExceeded)
+	}
+	if err := sleepWithContext(ctx, delay); err != nil {
+		l.release()
+		return err
+	}
+	return nil
+}
+
+// release returns a previously reserved token to the bucket
+func (l *RateLimiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tokens++
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(20, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Errorf("RateLimiter Wait failed: %s", err)
+			return
+		}
+	}
+	// Two requests are permitted by the burst, the remaining two require 50ms each
+	if elapsed := time.Since(start); elapsed < time.Millisecond*90 {
+		t.Errorf("RateLimiter Wait failed, expected at least 90ms of throttling, got: %s", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_Nil(t *testing.T) {
+	var l *RateLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("RateLimiter Wait on nil RateLimiter failed: %s", err)
+	}
+}
+
+func TestRateLimiter_Wait_Context(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("RateLimiter Wait failed: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RateLimiter Wait was expected to fail with context.DeadlineExceeded, got: %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 20)
+		cancel()
+	}()
+	err = l.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RateLimiter Wait was expected to fail with context.Canceled, got: %v", err)
+	}
+	if l.tokens < -1 {
+		t.Errorf("RateLimiter Wait failed, expected reservations to be released, got %f tokens", l.tokens)
+	}
+}
+
+func TestHTTPClient_GetWithContext_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := New(WithAPIBaseURL(server.URL+"/v02"), WithGeocoderURL(server.URL+"/osm"),
+		WithAPIRateLimit(0.1, 1))
+	if c.config.geocoderRateLimiter != nil {
+		t.Errorf("New failed, expected no default rate limit for custom geocoder URL")
+	}
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL+"/v02/current"); err != nil {
+		t.Errorf("HTTPClient GetWithContext failed: %s", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.httpClient.GetWithContext(ctx, server.URL+"/v02/current"); !errors.Is(err,
+		context.DeadlineExceeded) {
+		t.Errorf("HTTPClient GetWithContext was expected to be rate limited, got: %v", err)
+	}
+	if _, err := c.httpClient.GetWithContext(ctx, server.URL+"/osm/search"); err != nil {
+		t.Errorf("HTTPClient GetWithContext for geocoder was not expected to be rate limited: %s", err)
+	}
+}
+
+func TestNew_DefaultGeocoderRateLimit(t *testing.T) {
+	c := New()
+	if c.config.geocoderRateLimiter == nil {
+		t.Errorf("New failed, expected default rate limit for OSM Nominatim API")
+		return
+	}
+	if c.config.geocoderRateLimiter.rate != DefaultGeocoderRateLimit {
+		t.Errorf("New failed, expected rate limit: %d, got: %f", DefaultGeocoderRateLimit,
+			c.config.geocoderRateLimiter.rate)
+	}
+	c = New(WithGeocoderRateLimit(0.5, 1))
+	if c.config.geocoderRateLimiter.rate != 0.5 {
+		t.Errorf("New failed, expected rate limit: %f, got: %f", 0.5, c.config.geocoderRateLimiter.rate)
+	}
+}
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+// Package replay provides a record/replay http.RoundTripper that captures real HTTP
+// exchanges with the Meteologix and OSM Nominatim APIs into fixture files and replays
+// them, so that tests can run deterministically and without network access.
+//
+// Credentials (API keys, bearer tokens, basic auth and cookies) are scrubbed before an
+// exchange is recorded. The Transport can be used with the meteologix.WithTransport option.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// ModeReplay replays the recorded interactions of a cassette file. Requests that
+	// have not been recorded fail with ErrInteractionNotFound.
+	ModeReplay Mode = iota
+	// ModeRecord sends all requests to the upstream API and records the interactions.
+	ModeRecord
+	// ModeAuto replays the cassette file if it exists. Otherwise it behaves like ModeRecord.
+	ModeAuto
+)
+
+// RedactedValue is the placeholder for credentials that have been scrubbed from a recording
+const RedactedValue = "REDACTED"
+
+var (
+	// ErrInteractionNotFound is returned in replay mode if no recorded interaction matches
+	// the request
+	ErrInteractionNotFound = errors.New("no recorded interaction found for request")
+	// ErrNotRecording is returned by Save if the Transport is not in recording mode
+	ErrNotRecording = errors.New("transport is not in recording mode")
+)
+
+var (
+	// scrubbedHeaders is a list of HTTP headers that are removed from recordings
+	scrubbedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie", "X-API-Key"}
+	// scrubbedQueryParams is a list of URL query parameters that are redacted in recordings
+	scrubbedQueryParams = []string{"access_token", "api_key", "apikey", "key", "password", "token"}
+)
+
+// Mode is a type wrapper for an int and represents the operating mode of the Transport
+type Mode int
+
+// Transport is a http.RoundTripper that records or replays HTTP interactions to/from
+// a cassette file. It is safe for concurrent use.
+type Transport struct {
+	// cassette holds the recorded or loaded interactions
+	cassette Cassette
+	// mutex protects the cassette and the replayed interactions
+	mutex sync.Mutex
+	// path is the file path of the cassette file
+	path string
+	// recording is true, if the Transport is recording interactions
+	recording bool
+	// replayed holds the indices of already replayed interactions
+	replayed map[int]bool
+	// transport is the http.RoundTripper that is used for recording
+	transport http.RoundTripper
+}
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	// Interactions holds the recorded HTTP interactions
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP request and response
+type Interaction struct {
+	// Request is the recorded Request
+	Request Request `json:"request"`
+	// Response is the recorded Response
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request
+type Request struct {
+	// Header holds the scrubbed HTTP request headers
+	Header http.Header `json:"header,omitempty"`
+	// Method is the HTTP method of the request
+	Method string `json:"method"`
+	// URL is the scrubbed URL of the request
+	URL string `json:"url"`
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	// Body is the HTTP response body
+	Body string `json:"body"`
+	// Header holds the scrubbed HTTP response headers
+	Header http.Header `json:"header,omitempty"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"status"`
+}
+
+// NewTransport returns a new Transport for the cassette file at the given path in the
+// given Mode. In ModeRecord (or ModeAuto without existing cassette file), requests are
+// sent using the given http.RoundTripper. If it is nil, http.DefaultTransport is used.
+func NewTransport(path string, mode Mode, transport http.RoundTripper) (*Transport, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	replayTransport := &Transport{
+		path:      path,
+		replayed:  make(map[int]bool),
+		transport: transport,
+	}
+
+	switch mode {
+	case ModeRecord:
+		replayTransport.recording = true
+		return replayTransport, nil
+	case ModeAuto:
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			replayTransport.recording = true
+			return replayTransport, nil
+		}
+	case ModeReplay:
+	default:
+		return nil, fmt.Errorf("unsupported replay mode: %d", mode)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette file: %w", err)
+	}
+	if err = json.Unmarshal(data, &replayTransport.cassette); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cassette file: %w", err)
+	}
+	return replayTransport, nil
+}
+
+// IsRecording returns true if the Transport is recording interactions
+func (t *Transport) IsRecording() bool {
+	return t.recording
+}
+
+// RoundTrip satisfies the http.RoundTripper interface for the Transport type
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.recording {
+		return t.record(request)
+	}
+	return t.replay(request)
+}
+
+// Save writes the recorded interactions to the cassette file. The directory of the
+// cassette file is created, if it does not exist yet
+func (t *Transport) Save() error {
+	if !t.recording {
+		return ErrNotRecording
+	}
+	t.mutex.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(t.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err = os.WriteFile(t.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette file: %w", err)
+	}
+	return nil
+}
+
+// record sends the request to the upstream API and records the interaction
+func (t *Transport) record(request *http.Request) (*http.Response, error) {
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(response.Body)
+	if closeErr := response.Body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: Request{
+			Header: scrubHeader(request.Header),
+			Method: request.Method,
+			URL:    scrubURL(request.URL),
+		},
+		Response: Response{
+			Body:       string(body),
+			Header:     scrubHeader(response.Header),
+			StatusCode: response.StatusCode,
+		},
+	}
+	t.mutex.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	t.mutex.Unlock()
+	return response, nil
+}
+
+// replay returns the recorded response for the request. Interactions that match the same
+// request are replayed in the order they were recorded. Once all of them have been
+// replayed, the last one is replayed repeatedly
+func (t *Transport) replay(request *http.Request) (*http.Response, error) {
+	requestURL := scrubURL(request.URL)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if interaction.Request.Method != request.Method || interaction.Request.URL != requestURL {
+			continue
+		}
+		match = i
+		if !t.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, request.Method, requestURL)
+	}
+	t.replayed[match] = true
+
+	recorded := t.cassette.Interactions[match].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Header:        header,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       request,
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+	}, nil
+}
+
+// scrubHeader returns a copy of the given http.Header without any credentials
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range scrubbedHeaders {
+		scrubbed.Del(name)
+	}
+	return scrubbed
+}
+
+// scrubURL returns the given URL with all user information and credential query
+// parameters redacted. The query parameters are sorted, so that the result can be
+// used for matching requests
+func scrubURL(requestURL *url.URL) string {
+	scrubbed := *requestURL
+	if scrubbed.User != nil {
+		scrubbed.User = url.User(RedactedValue)
+	}
+	query := scrubbed.Query()
+	for key := range query {
+		for _, param := range scrubbedQueryParams {
+			if strings.EqualFold(key, param) {
+				query.Set(key, RedactedValue)
+			}
+		}
+	}
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
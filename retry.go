@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts (including the
+	// initial request) of a RetryPolicy
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default backoff duration before the first retry
+	DefaultRetryInitialBackoff = time.Millisecond * 500
+	// DefaultRetryMaxBackoff is the default upper limit for the backoff duration between
+	// two attempts
+	DefaultRetryMaxBackoff = time.Second * 10
+)
+
+// RetryPolicy defines if and how failed HTTP requests are retried by the HTTPClient.
+//
+// Only idempotent requests are retried. A request is considered retryable if it failed
+// with a transport error or if the server responded with HTTP 429, 500, 502, 503 or 504.
+// Errors that occur before a request is sent (e.g. rejections of a RequestHook or a
+// failing Authenticator) are not retried. Between attempts the HTTPClient waits for an
+// exponentially growing, jittered backoff duration. For HTTP 429 and 503 responses, a
+// Retry-After header sent by the server is honored, as long as it does not exceed the
+// MaxBackoff. Otherwise the request is not retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the initial request
+	MaxAttempts int
+	// InitialBackoff is the backoff duration before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper limit for the exponential backoff duration
+	MaxBackoff time.Duration
+	// DisableJitter disables the randomization of the backoff duration
+	DisableJitter bool
+}
+
+// transportError wraps an error of the underlying http.Client, so that transport errors
+// can be distinguished from errors that occur before a request is sent
+type transportError struct {
+	err error
+}
+
+// Error satisfies the error interface for the transportError type
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error of the transportError
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with the default values
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+	}
+}
+
+// attempts returns the maximum number of attempts for the RetryPolicy
+func (p *RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return DefaultRetryMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// retryable returns true if a request with the given method, that resulted in the given
+// http.Response and error, should be retried
+func (p *RetryPolicy) retryable(ctx context.Context, method string, response *http.Response, err error) bool {
+	if p == nil || err == nil || ctx.Err() != nil {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// Without a response, only transport errors are retried
+	if response == nil {
+		var transportErr *transportError
+		return errors.As(err, &transportErr)
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay returns the duration to wait before the next attempt. It will return false, if
+// waiting for that duration would exceed the deadline of the given context or if the
+// server requested a Retry-After duration that exceeds the MaxBackoff
+func (p *RetryPolicy) delay(ctx context.Context, attempt int, response *http.Response) (time.Duration, bool) {
+	initialBackoff := p.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = DefaultRetryInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	backoff := maxBackoff
+	if shift := attempt - 1; shift < 32 {
+		if exponential := initialBackoff << shift; exponential > 0 && exponential < maxBackoff {
+			backoff = exponential
+		}
+	}
+	if !p.DisableJitter && backoff > 1 {
+		half := backoff / 2
+		backoff = half + time.Duration(rand.Int63n(int64(backoff-half)))
+	}
+
+	if response != nil && (response.StatusCode == http.StatusTooManyRequests ||
+		response.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok && retryAfter > backoff {
+			if retryAfter > maxBackoff {
+				return retryAfter, false
+			}
+			backoff = retryAfter
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+		return backoff, false
+	}
+	return backoff, true
+}
+
+// parseRetryAfter parses the value of a Retry-After HTTP header, which can either be
+// a number of seconds or a HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	duration := time.Until(date)
+	if duration < 0 {
+		duration = 0
+	}
+	return duration, true
+}
+
+// sleepWithContext waits for the given duration or until the given context is done. In
+// the latter case the context error is returned
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_GetWithContext_Retry(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Status code of the failing responses
+		s int
+		// Number of failing responses before success
+		f int32
+		// Expected number of requests
+		er int32
+		// Should fail
+		sf bool
+	}{
+		{"HTTP 503 once", http.StatusServiceUnavailable, 1, 2, false},
+		{"HTTP 500 twice", http.StatusInternalServerError, 2, 3, false},
+		{"HTTP 429 too often", http.StatusTooManyRequests, 5, 3, true},
+		{"HTTP 400 not retried", http.StatusBadRequest, 1, 1, true},
+		{"HTTP 401 not retried", http.StatusUnauthorized, 1, 1, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", MIMETypeJSON)
+				if atomic.AddInt32(&requests, 1) <= tc.f {
+					w.WriteHeader(tc.s)
+					_, _ = w.Write([]byte(`{"status":0}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"code":200,"description":"OK"}`))
+			}))
+			defer server.Close()
+
+			c := New(WithRetryPolicy(RetryPolicy{
+				MaxAttempts: 3, InitialBackoff: time.Millisecond,
+				MaxBackoff: time.Millisecond * 5,
+			}))
+			_, err := c.httpClient.GetWithContext(context.Background(), server.URL)
+			if err != nil && !tc.sf {
+				t.Errorf("HTTPClient GetWithContext with retry failed: %s", err)
+			}
+			if err == nil && tc.sf {
+				t.Errorf("HTTPClient GetWithContext with retry was supposed to fail, but didn't")
+			}
+			if requests != tc.er {
+				t.Errorf("HTTPClient GetWithContext with retry failed, expected %d requests, got: %d",
+					tc.er, requests)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_GetWithContext_RetryDisabled(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":503}`))
+	}))
+	defer server.Close()
+
+	c := New()
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL); err == nil {
+		t.Errorf("HTTPClient GetWithContext was supposed to fail, but didn't")
+	}
+	if requests != 1 {
+		t.Errorf("HTTPClient GetWithContext without retry policy failed, expected 1 request, got: %d",
+			requests)
+	}
+}
+
+func TestHTTPClient_GetWithContext_RetryAfterExceedsDeadline(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"status":429}`))
+	}))
+	defer server.Close()
+
+	c := New(WithRetryPolicy(DefaultRetryPolicy()))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	_, err := c.httpClient.GetWithContext(ctx, server.URL)
+	if err == nil {
+		t.Errorf("HTTPClient GetWithContext was supposed to fail, but didn't")
+		return
+	}
+	var apiError APIError
+	if !errors.As(err, &apiError) {
+		t.Errorf("HTTPClient GetWithContext was supposed to return the APIError, got: %s", err)
+	}
+	if requests != 1 {
+		t.Errorf("HTTPClient GetWithContext failed, expected 1 request, got: %d", requests)
+	}
+}
+
+func TestHTTPClient_GetWithContext_RetryHookRejection(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", MIMETypeJSON)
+		_, _ = w.Write([]byte(`{"code":200,"description":"OK"}`))
+	}))
+	defer server.Close()
+
+	var calls int32
+	hook := func(*http.Request) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("hook rejects")
+	}
+	c := New(WithRequestHook(hook), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3, InitialBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond * 5,
+	}))
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL); err == nil {
+		t.Errorf("HTTPClient GetWithContext with rejecting hook was supposed to fail, but didn't")
+	}
+	if calls != 1 || requests != 0 {
+		t.Errorf("HTTPClient GetWithContext with rejecting hook failed, expected 1 hook call and no "+
+			"requests, got: %d/%d", calls, requests)
+	}
+
+	// Transport errors are retried
+	server.Close()
+	calls = 0
+	c = New(WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3, InitialBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond * 5,
+	}), WithRequestHook(func(*http.Request) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}))
+	if _, err := c.httpClient.GetWithContext(context.Background(), server.URL); err == nil {
+		t.Errorf("HTTPClient GetWithContext with closed server was supposed to fail, but didn't")
+	}
+	if calls != 3 {
+		t.Errorf("HTTPClient GetWithContext with transport error failed, expected 3 attempts, got: %d", calls)
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second * 5, DisableJitter: true}
+	tt := []struct {
+		// Attempt
+		a int
+		// Response
+		r *http.Response
+		// Expected delay
+		ed time.Duration
+		// Expected to retry
+		ok bool
+	}{
+		{1, nil, time.Second, true},
+		{2, nil, time.Second * 2, true},
+		{3, nil, time.Second * 4, true},
+		{4, nil, time.Second * 5, true},
+		{100, nil, time.Second * 5, true},
+		{
+			1, &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"3"}},
+			},
+			time.Second * 3, true,
+		},
+		{
+			1, &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": []string{"86400"}},
+			},
+			time.Hour * 24, false,
+		},
+		{
+			1, &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Retry-After": []string{"7"}},
+			},
+			time.Second, true,
+		},
+	}
+	for _, tc := range tt {
+		d, ok := p.delay(context.Background(), tc.a, tc.r)
+		if ok != tc.ok {
+			t.Errorf("RetryPolicy delay failed for attempt %d, expected ok: %t, got: %t", tc.a, tc.ok, ok)
+		}
+		if d != tc.ed {
+			t.Errorf("RetryPolicy delay failed, expected: %s, got: %s", tc.ed, d)
+		}
+	}
+
+	p.DisableJitter = false
+	for i := 0; i < 100; i++ {
+		d, _ := p.delay(context.Background(), 2, nil)
+		if d < time.Second || d > time.Second*2 {
+			t.Errorf("RetryPolicy delay with jitter failed, expected 1s-2s, got: %s", d)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tt := []struct {
+		// Header value
+		v string
+		// Expected duration
+		ed time.Duration
+		// Expected ok
+		eo bool
+	}{
+		{"", 0, false},
+		{"120", time.Second * 120, true},
+		{"-1", 0, false},
+		{"invalid", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+	}
+	for _, tc := range tt {
+		d, ok := parseRetryAfter(tc.v)
+		if ok != tc.eo {
+			t.Errorf("parseRetryAfter of %q failed, expected ok: %t, got: %t", tc.v, tc.eo, ok)
+		}
+		if d != tc.ed {
+			t.Errorf("parseRetryAfter of %q failed, expected: %s, got: %s", tc.v, tc.ed, d)
+		}
+	}
+}
@@ -5,7 +5,7 @@
 package meteologix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -97,8 +97,18 @@ type Precision int
 // that you are allowed to get all data from this station.
 //
 // See: https://api.kachelmannwetter.com/v02/_doc.html#/operations/get_station_search
-func (c *Client) StationSearchByCoordinates(latitude, longitude float64) ([]Station, error) {
-	return c.StationSearchByCoordinatesWithinRadius(latitude, longitude, DefaultRadius)
+func (c *Client) StationSearchByCoordinates(latitude, longitude float64, options ...CallOption,
+) ([]Station, error) {
+	return c.StationSearchByCoordinatesContext(context.Background(), latitude, longitude, options...)
+}
+
+// StationSearchByCoordinatesContext returns a list of available weather stations
+// based on the given latitude, longitude coordinates within the default
+// radius using the provided context for the API request
+func (c *Client) StationSearchByCoordinatesContext(ctx context.Context, latitude, longitude float64,
+	options ...CallOption,
+) ([]Station, error) {
+	return c.StationSearchByCoordinatesWithinRadiusContext(ctx, latitude, longitude, DefaultRadius, options...)
 }
 
 // StationSearchByLocation returns a list of available weather stations
@@ -112,8 +122,16 @@ func (c *Client) StationSearchByCoordinates(latitude, longitude float64) ([]Stat
 // that you are allowed to get all data from this station.
 //
 // See: https://api.kachelmannwetter.com/v02/_doc.html#/operations/get_station_search
-func (c *Client) StationSearchByLocation(location string) ([]Station, error) {
-	return c.StationSearchByLocationWithinRadius(location, DefaultRadius)
+func (c *Client) StationSearchByLocation(location string, options ...CallOption) ([]Station, error) {
+	return c.StationSearchByLocationContext(context.Background(), location, options...)
+}
+
+// StationSearchByLocationContext returns a list of available weather stations
+// based on the given location string within the default radius using the
+// provided context for the API requests
+func (c *Client) StationSearchByLocationContext(ctx context.Context, location string, options ...CallOption,
+) ([]Station, error) {
+	return c.StationSearchByLocationWithinRadiusContext(ctx, location, DefaultRadius, options...)
 }
 
 // StationSearchByLocationWithinRadius returns a list of available weather
@@ -127,12 +145,26 @@ func (c *Client) StationSearchByLocation(location string) ([]Station, error) {
 // that you are allowed to get all data from this station.
 //
 // See: https://api.kachelmannwetter.com/v02/_doc.html#/operations/get_station_search
-func (c *Client) StationSearchByLocationWithinRadius(location string, radius int) ([]Station, error) {
-	geoLocation, err := c.GetGeoLocationByName(location)
+func (c *Client) StationSearchByLocationWithinRadius(location string, radius int, options ...CallOption,
+) ([]Station, error) {
+	return c.StationSearchByLocationWithinRadiusContext(context.Background(), location, radius, options...)
+}
+
+// StationSearchByLocationWithinRadiusContext returns a list of available weather
+// stations based on the given location string and radius using the provided
+// context for the API requests
+func (c *Client) StationSearchByLocationWithinRadiusContext(ctx context.Context, location string,
+	radius int, options ...CallOption,
+) ([]Station, error) {
+	ctx, cancel, _ := c.callContext(ctx, options)
+	defer cancel()
+
+	geoLocation, err := c.GetGeoLocationByNameContext(ctx, location, options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed too look up location details: %w", err)
 	}
-	return c.StationSearchByCoordinatesWithinRadius(geoLocation.Latitude, geoLocation.Longitude, radius)
+	return c.StationSearchByCoordinatesWithinRadiusContext(ctx, geoLocation.Latitude, geoLocation.Longitude,
+		radius, options...)
 }
 
 // StationSearchByCoordinatesWithinRadius returns a list of available weather stations
@@ -146,10 +178,24 @@ func (c *Client) StationSearchByLocationWithinRadius(location string, radius int
 // that you are allowed to get all data from this station.
 //
 // See: https://api.kachelmannwetter.com/v02/_doc.html#/operations/get_station_search
-func (c *Client) StationSearchByCoordinatesWithinRadius(latitude, longitude float64, radius int) ([]Station, error) {
+func (c *Client) StationSearchByCoordinatesWithinRadius(latitude, longitude float64, radius int,
+	options ...CallOption,
+) ([]Station, error) {
+	return c.StationSearchByCoordinatesWithinRadiusContext(context.Background(), latitude, longitude, radius,
+		options...)
+}
+
+// StationSearchByCoordinatesWithinRadiusContext returns a list of available weather
+// stations based on the given latitude, longitude coordinates and radius using the
+// provided context for the API request
+func (c *Client) StationSearchByCoordinatesWithinRadiusContext(ctx context.Context, latitude, longitude float64,
+	radius int, options ...CallOption,
+) ([]Station, error) {
 	if radius < 1 {
 		return nil, ErrRadiusTooSmall
 	}
+	ctx, cancel, call := c.callContext(ctx, options)
+	defer cancel()
 
 	apiURL, err := url.Parse(fmt.Sprintf("%s/station/search/%f/%f",
 		c.config.apiURL, latitude, longitude))
@@ -160,17 +206,20 @@ func (c *Client) StationSearchByCoordinatesWithinRadius(latitude, longitude floa
 	query.Add("radius", fmt.Sprintf("%d", radius))
 	apiURL.RawQuery = query.Encode()
 
-	response, err := c.httpClient.Get(apiURL.String())
+	response, cached, err := c.getCached(ctx, call, apiURL.String())
 	if err != nil {
 		return nil, fmt.Errorf("API request failed: %w", err)
 	}
 	var stations []Station
-	if err = json.Unmarshal(response, &stations); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal API response JSON: %w", err)
+	if err = c.httpClient.unmarshalResponse(ctx, apiURL.String(), response, &stations); err != nil {
+		return nil, err
 	}
 	if len(stations) < 1 {
 		return nil, ErrNoStationFound
 	}
+	if !cached {
+		c.setCached(call, apiURL.String(), response, DefaultCacheTTLStationSearch)
+	}
 	sort.SliceStable(stations, func(i, j int) bool { return stations[i].Distance < stations[j].Distance })
 
 	return stations, nil
@@ -36,7 +36,7 @@ func TestClient_StationSearchByLocation(t *testing.T) {
 }
 
 func TestClient_StationSearchByLocation_Fail(t *testing.T) {
-	c := New(WithUsername("invalid"), WithPassword("invalid"))
+	c := New(WithUsername("invalid"), WithPassword("invalid"), withCassette(t, "station"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
@@ -49,7 +49,7 @@ func TestClient_StationSearchByLocation_Fail(t *testing.T) {
 		t.Errorf("StationSearchByLocation was supposed to throw a APIError but didn't: %s",
 			err)
 	}
-	c = New(WithAPIKey("invalid"))
+	c = New(WithAPIKey("invalid"), withCassette(t, "station"))
 	_, err = c.StationSearchByLocation("Cologne, Germany")
 	if err == nil {
 		t.Errorf("StationSearchByLocation was supposed to fail but didn't")
@@ -100,7 +100,7 @@ func TestClient_StationSearchByCoordinates_Mock(t *testing.T) {
 		Type:           &ty,
 	}
 
-	c := New(withMockAPI())
+	c := New(withMockAPI(), withCassette(t, "station"))
 	if c == nil {
 		t.Errorf("failed to create new Client, got nil")
 		return
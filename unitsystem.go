@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+const (
+	// UnitSystemMetric represents the metric unit system, in which the API returns
+	// temperatures in °C, speeds in m/s, precipitation in mm, heights in m, densities
+	// in kg/m³ and pressures in hPa. This is the default unit system
+	UnitSystemMetric UnitSystem = "metric"
+	// UnitSystemImperial represents the imperial unit system, in which the API returns
+	// temperatures in °F, speeds in mph, precipitation in in, heights in ft, densities
+	// in lb/ft³ and pressures in inHg
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// UnitSystem is the unit system that is requested from the Meteologix API and that is
+// returned as "systemOfUnits" in the API responses.
+//
+// Independent of the UnitSystem that was requested, all value types (Temperature, Speed,
+// Precipitation, Height, Density and Pressure) hold their values in the metric base units, so that
+// their conversion methods always return correct results. Values of an unknown UnitSystem
+// are treated as metric values
+type UnitSystem string
+
+// String satisfies the fmt.Stringer interface for the UnitSystem type
+func (u UnitSystem) String() string {
+	return string(u)
+}
+
+// supported returns true if the UnitSystem is supported by the API
+func (u UnitSystem) supported() bool {
+	return u == UnitSystemMetric || u == UnitSystemImperial
+}
+
+// celsius converts a temperature value of the UnitSystem to °C
+func (u UnitSystem) celsius(value float64) float64 {
+	if u == UnitSystemImperial {
+		return (value - 32) * 5 / 9
+	}
+	return value
+}
+
+// hectoPascal converts a pressure value of the UnitSystem to hPa
+func (u UnitSystem) hectoPascal(value float64) float64 {
+	if u == UnitSystemImperial {
+		return value / MultiplierInHg
+	}
+	return value
+}
+
+// kilogramPerCubicMeter converts a density value of the UnitSystem to kg/m³
+func (u UnitSystem) kilogramPerCubicMeter(value float64) float64 {
+	if u == UnitSystemImperial {
+		return value / MultiplierPoundsPerCubicFoot
+	}
+	return value
+}
+
+// meter converts a height value of the UnitSystem to m
+func (u UnitSystem) meter(value float64) float64 {
+	if u == UnitSystemImperial {
+		return value / MultiplierFeet
+	}
+	return value
+}
+
+// meterPerSecond converts a speed value of the UnitSystem to m/s
+func (u UnitSystem) meterPerSecond(value float64) float64 {
+	if u == UnitSystemImperial {
+		return value / MultiplierMPH
+	}
+	return value
+}
+
+// milliMeter converts a precipitation value of the UnitSystem to mm
+func (u UnitSystem) milliMeter(value float64) float64 {
+	if u == UnitSystemImperial {
+		return value / MultiplierInch
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 Winni Neessen <wn@neessen.dev>
+//
+// SPDX-License-Identifier: MIT
+
+package meteologix
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestUnitSystem_Conversion(t *testing.T) {
+	tt := []struct {
+		// Test name
+		n string
+		// Conversion function
+		f func(float64) float64
+		// Value in the UnitSystem
+		v float64
+		// Expected value in the metric base unit
+		e float64
+	}{
+		{"Metric temperature", UnitSystemMetric.celsius, 21.5, 21.5},
+		{"Imperial temperature", UnitSystemImperial.celsius, 212, 100},
+		{"Imperial freezing point", UnitSystemImperial.celsius, 32, 0},
+		{"Unknown temperature", UnitSystem("unknown").celsius, 12.3, 12.3},
+		{"Metric pressure", UnitSystemMetric.hectoPascal, 1013.25, 1013.25},
+		{"Imperial pressure", UnitSystemImperial.hectoPascal, 29.92, 1013.21},
+		{"Metric height", UnitSystemMetric.meter, 1.2, 1.2},
+		{"Imperial height", UnitSystemImperial.meter, 10, 3.048},
+		{"Metric speed", UnitSystemMetric.meterPerSecond, 5, 5},
+		{"Imperial speed", UnitSystemImperial.meterPerSecond, 22.36936, 10},
+		{"Metric precipitation", UnitSystemMetric.milliMeter, 2.5, 2.5},
+		{"Imperial precipitation", UnitSystemImperial.milliMeter, 1, 25.4},
+		{"Metric density", UnitSystemMetric.kilogramPerCubicMeter, 120, 120},
+		{"Imperial density", UnitSystemImperial.kilogramPerCubicMeter, 6.242796, 100},
+	}
+	for _, tc := range tt {
+		t.Run(tc.n, func(t *testing.T) {
+			if v := tc.f(tc.v); math.Abs(v-tc.e) > 0.01 {
+				t.Errorf("UnitSystem conversion failed, expected: %f, got: %f", tc.e, v)
+			}
+		})
+	}
+}
+
+func TestCurrentWeather_UnitSystem(t *testing.T) {
+	data := []byte(`{"systemOfUnits":"imperial","data":{` +
+		`"temp":{"dateTime":"2023-05-28T12:00:00Z","value":68},` +
+		`"windSpeed":{"dateTime":"2023-05-28T12:00:00Z","value":22.36936},` +
+		`"prec1h":{"dateTime":"2023-05-28T12:00:00Z","value":0.5},` +
+		`"snowHeight":{"dateTime":"2023-05-28T12:00:00Z","value":3.28084},` +
+		`"snowAmount":{"dateTime":"2023-05-28T12:00:00Z","value":6.242796},` +
+		`"pressureMsl":{"dateTime":"2023-05-28T12:00:00Z","value":29.92}}}`)
+	var cw CurrentWeather
+	if err := json.Unmarshal(data, &cw); err != nil {
+		t.Errorf("failed to unmarshal CurrentWeather JSON: %s", err)
+		return
+	}
+	if cw.Units() != UnitSystemImperial {
+		t.Errorf("CurrentWeather Units failed, expected: %s, got: %s", UnitSystemImperial, cw.Units())
+	}
+	if v := cw.Temperature().String(); v != "20.0°C" {
+		t.Errorf("CurrentWeather Temperature failed, expected: %s, got: %s", "20.0°C", v)
+	}
+	if v := cw.Temperature().FahrenheitString(); v != "68.0°F" {
+		t.Errorf("CurrentWeather Temperature failed, expected: %s, got: %s", "68.0°F", v)
+	}
+	if v := cw.WindSpeed().String(); v != "10.0m/s" {
+		t.Errorf("CurrentWeather WindSpeed failed, expected: %s, got: %s", "10.0m/s", v)
+	}
+	if v := cw.WindSpeed().MPHString(); v != "22.4mi/h" {
+		t.Errorf("CurrentWeather WindSpeed failed, expected: %s, got: %s", "22.4mi/h", v)
+	}
+	if v := cw.Precipitation(Timespan1Hour).String(); v != "12.7mm" {
+		t.Errorf("CurrentWeather Precipitation failed, expected: %s, got: %s", "12.7mm", v)
+	}
+	if v := cw.Precipitation(Timespan1Hour).InchString(); v != "0.50in" {
+		t.Errorf("CurrentWeather Precipitation failed, expected: %s, got: %s", "0.50in", v)
+	}
+	if v := cw.SnowHeight().String(); v != "1.000m" {
+		t.Errorf("CurrentWeather SnowHeight failed, expected: %s, got: %s", "1.000m", v)
+	}
+	if v := cw.SnowHeight().FeetString(); v != "3.281ft" {
+		t.Errorf("CurrentWeather SnowHeight failed, expected: %s, got: %s", "3.281ft", v)
+	}
+	if v := cw.SnowHeight().InchString(); v != "39.370in" {
+		t.Errorf("CurrentWeather SnowHeight failed, expected: %s, got: %s", "39.370in", v)
+	}
+	if v := cw.SnowAmount().String(); v != "100.0kg/m³" {
+		t.Errorf("CurrentWeather SnowAmount failed, expected: %s, got: %s", "100.0kg/m³", v)
+	}
+	if v := cw.PressureMSL().String(); v != "1013.2hPa" {
+		t.Errorf("CurrentWeather PressureMSL failed, expected: %s, got: %s", "1013.2hPa", v)
+	}
+	if v := cw.PressureMSL().InHgString(); v != "29.92inHg" {
+		t.Errorf("CurrentWeather PressureMSL failed, expected: %s, got: %s", "29.92inHg", v)
+	}
+}